@@ -0,0 +1,265 @@
+package gql_auto
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// RegisterInterface registers the Go interface iface as a GraphQL interface
+// implemented by the structs informed.
+//
+// The fields of the GraphQL interface are the fields shared by all the
+// implementations, and the objects of the implementations list the interface
+// in their interfaces. The concrete type of the values is resolved by
+// reflecting on the runtime value.
+//
+// A struct can implement several interfaces and be a member of unions, but
+// the interfaces must be registered before its object is returned by the
+// encoder, or by the types reachable from the values returned, i.e. the
+// members of a union, and before it is added to a schema.
+//
+// ```
+//
+//	_, err := enc.RegisterInterface(
+//	    reflect.TypeOf((*Shape)(nil)).Elem(),
+//	    reflect.TypeOf(Circle{}),
+//	    reflect.TypeOf(Square{}),
+//	)
+//
+// ```
+func (enc *Encoder) RegisterInterface(iface reflect.Type, impls ...reflect.Type) (*graphql.Interface, error) {
+	return build(enc, func() (*graphql.Interface, error) {
+		return enc.registerInterface(iface, impls)
+	})
+}
+
+func (enc *Encoder) registerInterface(iface reflect.Type, impls []reflect.Type) (*graphql.Interface, error) {
+	impls, err := enc.checkAbstract(iface, impls)
+	if err != nil {
+		return nil, err
+	}
+	for _, impl := range impls {
+		if obj, ok := enc.getType(impl); ok && !enc.openObjects[obj.(*graphql.Object)] {
+			return nil, fmt.Errorf("%s must be registered before it is returned by the encoder or added to a schema", impl)
+		}
+	}
+
+	name := enc.naming(iface)
+	err = enc.claimName(name, iface)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := map[reflect.Type]*graphql.Object{}
+	r := graphql.NewInterface(graphql.InterfaceConfig{
+		Name: name,
+		// The fields are evaluated once the implementations are built.
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return sharedFields(impls, objects)
+		}),
+		ResolveType: resolveTypeOf(objects),
+	})
+	enc.registerType(iface, r)
+
+	for _, impl := range impls {
+		setCache(enc, enc.interfaces, impl, append(enc.interfaces[impl], r))
+	}
+	for _, impl := range impls {
+		obj, err := enc.structOf(impl)
+		if err != nil {
+			return nil, err
+		}
+		objects[impl] = obj
+	}
+
+	freeze(r)
+	if r.Error() != nil {
+		return nil, r.Error()
+	}
+	return r, nil
+}
+
+// RegisterUnion registers the Go interface iface as a GraphQL union of the
+// structs informed. The concrete type of the values is resolved by reflecting
+// on the runtime value.
+func (enc *Encoder) RegisterUnion(iface reflect.Type, members ...reflect.Type) (*graphql.Union, error) {
+	return build(enc, func() (*graphql.Union, error) {
+		return enc.registerUnion(iface, members)
+	})
+}
+
+func (enc *Encoder) registerUnion(iface reflect.Type, members []reflect.Type) (*graphql.Union, error) {
+	members, err := enc.checkAbstract(iface, members)
+	if err != nil {
+		return nil, err
+	}
+
+	name := enc.naming(iface)
+	err = enc.claimName(name, iface)
+	if err != nil {
+		return nil, err
+	}
+
+	objects := map[reflect.Type]*graphql.Object{}
+	types := make([]*graphql.Object, 0, len(members))
+	r := graphql.NewUnion(graphql.UnionConfig{
+		Name: name,
+		// The types are evaluated once the members are built.
+		Types: graphql.UnionTypesThunk(func() []*graphql.Object {
+			return types
+		}),
+		ResolveType: resolveTypeOf(objects),
+	})
+	enc.registerType(iface, r)
+
+	for _, member := range members {
+		obj, err := enc.structOf(member)
+		if err != nil {
+			return nil, err
+		}
+		objects[member] = obj
+		types = append(types, obj)
+	}
+
+	freeze(r)
+	if r.Error() != nil {
+		return nil, r.Error()
+	}
+	return r, nil
+}
+
+// checkAbstract checks that iface is an interface not registered yet, and
+// that the structs informed implement it. It returns the structs without
+// pointers.
+func (enc *Encoder) checkAbstract(iface reflect.Type, impls []reflect.Type) ([]reflect.Type, error) {
+	if iface.Kind() != reflect.Interface {
+		return nil, fmt.Errorf("%s is not an interface", iface)
+	}
+	// The unnamed interfaces, i.e. interface{}, have no type name.
+	if enc.naming(iface) == "" {
+		return nil, fmt.Errorf("%s has no name, only named interfaces can be registered", iface)
+	}
+	if _, ok := enc.getType(iface); ok {
+		return nil, fmt.Errorf("%s is already registered", iface)
+	}
+	if len(impls) == 0 {
+		return nil, fmt.Errorf("%s has no implementations", iface)
+	}
+
+	r := make([]reflect.Type, 0, len(impls))
+	for _, impl := range impls {
+		impl = cacheKey(impl)
+		if impl.Kind() != reflect.Struct {
+			return nil, fmt.Errorf("%s is not a struct", impl)
+		}
+		if !impl.Implements(iface) && !reflect.PtrTo(impl).Implements(iface) {
+			return nil, fmt.Errorf("%s does not implement %s", impl, iface)
+		}
+		r = append(r, impl)
+	}
+	return r, nil
+}
+
+// closeInterfaces initializes the interfaces of the open objects reachable
+// from v, the result of a builder, so they are not modified when they are
+// read by concurrent schemas. No interface can be registered for them
+// afterwards.
+//
+// The objects reachable from the closed objects are closed, so the open
+// objects are only reachable from the values returned by `ResolveType`.
+func (enc *Encoder) closeInterfaces(v interface{}) {
+	switch v := v.(type) {
+	case graphql.Type:
+		enc.closeReachable(v, map[graphql.Type]bool{})
+	case graphql.Field:
+		enc.closeReachable(v.Type, map[graphql.Type]bool{})
+	case *graphql.Field:
+		enc.closeReachable(v.Type, map[graphql.Type]bool{})
+	}
+}
+
+func (enc *Encoder) closeReachable(t graphql.Type, seen map[graphql.Type]bool) {
+	if t == nil || seen[t] {
+		return
+	}
+	seen[t] = true
+	switch t := t.(type) {
+	case *graphql.List:
+		enc.closeReachable(t.OfType, seen)
+	case *graphql.NonNull:
+		enc.closeReachable(t.OfType, seen)
+	case *graphql.Object:
+		if !enc.openObjects[t] {
+			return
+		}
+		deleteCache(enc, enc.openObjects, t)
+		for _, iface := range t.Interfaces() {
+			enc.closeReachable(iface, seen)
+		}
+		for _, field := range t.Fields() {
+			enc.closeReachable(field.Type, seen)
+		}
+	case *graphql.Interface:
+		for _, field := range t.Fields() {
+			enc.closeReachable(field.Type, seen)
+		}
+	case *graphql.Union:
+		for _, obj := range t.Types() {
+			enc.closeReachable(obj, seen)
+		}
+	}
+}
+
+// resolveTypeOf returns a function that resolves the object of a value by
+// its Go type.
+func resolveTypeOf(objects map[reflect.Type]*graphql.Object) graphql.ResolveTypeFn {
+	return func(p graphql.ResolveTypeParams) *graphql.Object {
+		t := reflect.TypeOf(p.Value)
+		if t == nil {
+			return nil
+		}
+		return objects[cacheKey(t)]
+	}
+}
+
+// sharedFields returns the fields present, with the same type, in all the
+// objects of the implementations.
+func sharedFields(impls []reflect.Type, objects map[reflect.Type]*graphql.Object) graphql.Fields {
+	r := graphql.Fields{}
+	if len(impls) == 0 {
+		return r
+	}
+
+	for name, field := range objects[impls[0]].Fields() {
+		shared := true
+		for _, impl := range impls[1:] {
+			other, ok := objects[impl].Fields()[name]
+			if !ok || other.Type.String() != field.Type.String() {
+				shared = false
+				break
+			}
+		}
+		if !shared {
+			continue
+		}
+
+		args := graphql.FieldConfigArgument{}
+		for _, arg := range field.Args {
+			args[arg.Name()] = &graphql.ArgumentConfig{
+				Type:         arg.Type,
+				DefaultValue: arg.DefaultValue,
+				Description:  arg.Description(),
+			}
+		}
+		r[name] = &graphql.Field{
+			Name:              name,
+			Type:              field.Type,
+			Args:              args,
+			Description:       field.Description,
+			DeprecationReason: field.DeprecationReason,
+		}
+	}
+	return r
+}
@@ -0,0 +1,243 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type AbstractShape interface {
+	Area() float64
+}
+
+type AbstractCircle struct {
+	Name   string        `graphql:"!name"`
+	Radius float64       `graphql:"radius"`
+	Parent AbstractShape `graphql:"parent"`
+}
+
+func (c AbstractCircle) Area() float64 {
+	return 3 * c.Radius * c.Radius
+}
+
+type AbstractSquare struct {
+	Name string  `graphql:"!name"`
+	Side float64 `graphql:"side"`
+}
+
+func (s *AbstractSquare) Area() float64 {
+	return s.Side * s.Side
+}
+
+type AbstractDrawing struct {
+	Main   AbstractShape   `graphql:"main"`
+	Shapes []AbstractShape `graphql:"shapes"`
+}
+
+func TestEncoder_RegisterInterface(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	iface, err := enc.RegisterInterface(
+		reflect.TypeOf((*AbstractShape)(nil)).Elem(),
+		reflect.TypeOf(AbstractCircle{}),
+		reflect.TypeOf(&AbstractSquare{}),
+	)
+	ass.NoError(err)
+	ass.Equal("AbstractShape", iface.Name())
+	ass.Len(iface.Fields(), 1)
+	ass.Contains(iface.Fields(), "name")
+
+	circle, err := enc.Struct(AbstractCircle{})
+	ass.NoError(err)
+	ass.Equal([]*graphql.Interface{iface}, circle.Interfaces())
+	ass.Equal(iface, circle.Fields()["parent"].Type)
+
+	schema, err := enc.SchemaBuilder().
+		Query("drawing", nil, AbstractDrawing{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return AbstractDrawing{
+				Main: &AbstractSquare{Name: "main", Side: 2},
+				Shapes: []AbstractShape{
+					AbstractCircle{Name: "c", Radius: 1, Parent: &AbstractSquare{Name: "p"}},
+					&AbstractSquare{Name: "s", Side: 3},
+				},
+			}, nil
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			drawing {
+				main { name ... on AbstractSquare { side } }
+				shapes {
+					__typename
+					name
+					... on AbstractCircle { radius parent { name } }
+					... on AbstractSquare { side }
+				}
+			}
+		}`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"drawing": map[string]interface{}{
+			"main": map[string]interface{}{"name": "main", "side": 2.0},
+			"shapes": []interface{}{
+				map[string]interface{}{"__typename": "AbstractCircle", "name": "c", "radius": 1.0, "parent": map[string]interface{}{"name": "p"}},
+				map[string]interface{}{"__typename": "AbstractSquare", "name": "s", "side": 3.0},
+			},
+		},
+	}, res.Data)
+
+	ass.Contains(gql_auto.PrintSchemaSDL(schema), "type AbstractCircle implements AbstractShape {")
+}
+
+type AbstractResult interface {
+	isResult()
+}
+
+type AbstractUser struct {
+	Name string `graphql:"!name"`
+}
+
+func (AbstractUser) isResult() {}
+
+type AbstractGroup struct {
+	Title   string           `graphql:"!title"`
+	Related []AbstractResult `graphql:"related"`
+}
+
+func (AbstractGroup) isResult() {}
+
+func TestEncoder_RegisterUnion(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	union, err := enc.RegisterUnion(
+		reflect.TypeOf((*AbstractResult)(nil)).Elem(),
+		reflect.TypeOf(AbstractUser{}),
+		reflect.TypeOf(AbstractGroup{}),
+	)
+	ass.NoError(err)
+	ass.Equal("AbstractResult", union.Name())
+	ass.Len(union.Types(), 2)
+
+	schema, err := enc.SchemaBuilder().
+		Query("search", nil, []AbstractResult{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return []AbstractResult{
+				AbstractUser{Name: "Duke"},
+				AbstractGroup{Title: "Joes", Related: []AbstractResult{AbstractUser{Name: "Scarlett"}}},
+			}, nil
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			search {
+				... on AbstractUser { name }
+				... on AbstractGroup { title related { ... on AbstractUser { name } } }
+			}
+		}`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"search": []interface{}{
+			map[string]interface{}{"name": "Duke"},
+			map[string]interface{}{"title": "Joes", "related": []interface{}{map[string]interface{}{"name": "Scarlett"}}},
+		},
+	}, res.Data)
+	ass.Contains(gql_auto.PrintSchemaSDL(schema), "union AbstractResult = AbstractGroup | AbstractUser")
+}
+
+func TestEncoder_RegisterInterfaceErrors(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	shape := reflect.TypeOf((*AbstractShape)(nil)).Elem()
+
+	_, err := gql_auto.NewEncoder().RegisterInterface(reflect.TypeOf(AbstractCircle{}), reflect.TypeOf(AbstractCircle{}))
+	ass.ErrorContains(err, "is not an interface")
+
+	_, err = gql_auto.NewEncoder().RegisterInterface(shape)
+	ass.ErrorContains(err, "has no implementations")
+
+	_, err = gql_auto.NewEncoder().RegisterInterface(reflect.TypeOf((*interface{ Area() float64 })(nil)).Elem(), reflect.TypeOf(AbstractCircle{}))
+	ass.ErrorContains(err, "interface { Area() float64 } has no name")
+	_, err = gql_auto.NewEncoder().RegisterUnion(reflect.TypeOf((*interface{})(nil)).Elem(), reflect.TypeOf(AbstractUser{}))
+	ass.ErrorContains(err, "interface {} has no name")
+
+	_, err = gql_auto.NewEncoder().RegisterInterface(shape, reflect.TypeOf(AbstractUser{}))
+	ass.ErrorContains(err, "does not implement")
+
+	enc := gql_auto.NewEncoder()
+	_, err = enc.Struct(AbstractSquare{})
+	ass.NoError(err)
+	_, err = enc.RegisterInterface(shape, reflect.TypeOf(AbstractSquare{}))
+	ass.ErrorContains(err, "must be registered before it is returned by the encoder or added to a schema")
+
+	// The types are closed by Types, i.e. when a schema is built.
+	enc = gql_auto.NewEncoder()
+	_, err = enc.RegisterInterface(reflect.TypeOf((*AbstractNode)(nil)).Elem(), reflect.TypeOf(AbstractSquare{}))
+	ass.NoError(err)
+	enc.Types()
+	_, err = enc.RegisterInterface(shape, reflect.TypeOf(AbstractSquare{}))
+	ass.ErrorContains(err, "must be registered before it is returned by the encoder or added to a schema")
+}
+
+type AbstractNode interface {
+	NodeName() string
+}
+
+func (s AbstractSquare) NodeName() string {
+	return s.Name
+}
+
+func (AbstractSquare) isResult() {}
+
+func TestEncoder_RegisterSeveralInterfaces(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	node, err := enc.RegisterInterface(reflect.TypeOf((*AbstractNode)(nil)).Elem(), reflect.TypeOf(AbstractSquare{}))
+	ass.NoError(err)
+	shape, err := enc.RegisterInterface(
+		reflect.TypeOf((*AbstractShape)(nil)).Elem(),
+		reflect.TypeOf(AbstractCircle{}),
+		reflect.TypeOf(AbstractSquare{}),
+	)
+	ass.NoError(err)
+	result, err := enc.RegisterUnion(
+		reflect.TypeOf((*AbstractResult)(nil)).Elem(),
+		reflect.TypeOf(AbstractUser{}),
+		reflect.TypeOf(AbstractSquare{}),
+	)
+	ass.NoError(err)
+	square, err := enc.Struct(AbstractSquare{})
+	ass.NoError(err)
+	ass.Contains(result.Types(), square)
+	ass.Equal([]*graphql.Interface{node, shape}, square.Interfaces())
+
+	schema, err := enc.SchemaBuilder().
+		Query("node", nil, (*AbstractNode)(nil), func(p graphql.ResolveParams) (interface{}, error) {
+			return AbstractSquare{Name: "s", Side: 2}, nil
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ node { ... on AbstractShape { name } ... on AbstractSquare { side } } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{"node": map[string]interface{}{"name": "s", "side": 2.0}}, res.Data)
+	ass.Contains(enc.PrintSDL(), "type AbstractSquare implements AbstractNode & AbstractShape {")
+}
@@ -2,13 +2,19 @@ package gql_auto
 
 import (
 	"github.com/graphql-go/graphql"
-	"sync"
 )
 
-var mutex = &sync.Mutex{}
+// AddField adds a field to an object built by the encoder. The object is
+// modified while holding the lock of the encoder.
+func (enc *Encoder) AddField(root *graphql.Object, field *graphql.Field) {
+	enc.mu.Lock()
+	root.AddFieldConfig(field.Name, field)
+	freeze(root)
+	enc.closeInterfaces(field.Type)
+	enc.mu.Unlock()
+}
 
+// AddField adds a field to an object built by the `DefaultEncoder`.
 func AddField(root *graphql.Object, field *graphql.Field) {
-	mutex.Lock()
-	root.AddFieldConfig(field.Name, field)
-	mutex.Unlock()
+	DefaultEncoder.AddField(root, field)
 }
@@ -163,6 +163,32 @@ func (option *withResolver) Apply(dst interface{}) error {
 	}
 }
 
+type withSubscribe struct {
+	subscribe graphql.FieldResolveFn
+}
+
+// WithSubscribe creates an `Option` that sets the function that provides the
+// events of a subscription.
+//
+// It can be applied to:
+// * Fields;
+func WithSubscribe(subscribe graphql.FieldResolveFn) Option {
+	return &withSubscribe{
+		subscribe: subscribe,
+	}
+}
+
+// Apply sets the subscribe function of the field.
+func (option *withSubscribe) Apply(dst interface{}) error {
+	switch t := dst.(type) {
+	case *graphql.Field:
+		t.Subscribe = option.subscribe
+		return nil
+	default:
+		return newErrNotSupported(dst)
+	}
+}
+
 type withArgs struct {
 	encoder *Encoder
 	args    interface{}
@@ -0,0 +1,121 @@
+package gql_auto
+
+import (
+	"errors"
+	"reflect"
+)
+
+// setCache sets the key of the cache m to value. During a build, the
+// previous value is kept in the undo log, so it is restored if the build
+// fails.
+func setCache[K comparable, V any](enc *Encoder, m map[K]V, key K, value V) {
+	if enc.undo != nil {
+		old, ok := m[key]
+		enc.undo = append(enc.undo, func() {
+			if ok {
+				m[key] = old
+			} else {
+				delete(m, key)
+			}
+		})
+	}
+	m[key] = value
+}
+
+// deleteCache deletes the key of the cache m, like `setCache`.
+func deleteCache[K comparable, V any](enc *Encoder, m map[K]V, key K) {
+	old, ok := m[key]
+	if !ok {
+		return
+	}
+	if enc.undo != nil {
+		enc.undo = append(enc.undo, func() {
+			m[key] = old
+		})
+	}
+	delete(m, key)
+}
+
+// build runs fn holding the lock of the encoder. The types are registered
+// before their fields are built, so self-referencing types resolve to
+// themselves. When fn fails, the caches are restored, so the types left
+// half-built are neither returned by later calls nor added to the schemas.
+//
+// When fn needs the result of a user callback, it is called without the
+// lock once the caches are restored, and fn is run again.
+func build[R any](enc *Encoder, fn func() (R, error)) (R, error) {
+	for {
+		r, err, call := buildOnce(enc, fn)
+		if call == nil {
+			return r, err
+		}
+		result := call.fn()
+
+		enc.mu.Lock()
+		// The callbacks are called once per type, unless they are called
+		// concurrently, in which case the first result is kept.
+		if _, ok := enc.callbacks[call.key]; !ok {
+			enc.callbacks[call.key] = result
+		}
+		enc.mu.Unlock()
+	}
+}
+
+func buildOnce[R any](enc *Encoder, fn func() (R, error)) (R, error, *pendingCallback) {
+	enc.mu.Lock()
+	defer enc.mu.Unlock()
+
+	// The undo log is not nil while the build is in progress, so only the
+	// entries added by the build are undone.
+	enc.undo = []func(){}
+	r, err := fn()
+	undo := enc.undo
+	enc.undo = nil
+	call := enc.pending
+	enc.pending = nil
+	if err != nil {
+		for i := len(undo) - 1; i >= 0; i-- {
+			undo[i]()
+		}
+		var zero R
+		return zero, err, call
+	}
+	enc.closeInterfaces(r)
+	return r, nil, nil
+}
+
+// callbackKey identifies the callback of a type, i.e. its `GraphqlType`
+// method.
+type callbackKey struct {
+	t      reflect.Type
+	method string
+}
+
+// pendingCallback is a callback needed by the build in progress.
+type pendingCallback struct {
+	key callbackKey
+	fn  func() interface{}
+}
+
+// errPendingCallback aborts the build in progress until the callback is
+// called by `build`.
+var errPendingCallback = errors.New("the build is waiting for a callback")
+
+// callback returns the result of the method of the type t called by fn. The
+// methods are implemented by the users, and they can build other types with
+// the encoder, i.e. by `Struct`, so they are not called holding the lock.
+// The first time, the build in progress is aborted, and the method is called
+// by `build` before the build is run again.
+func callback[R any](enc *Encoder, t reflect.Type, method string, fn func() R) (R, error) {
+	key := callbackKey{t: cacheKey(t), method: method}
+	if r, ok := enc.callbacks[key]; ok {
+		return r.(R), nil
+	}
+	if enc.pending == nil {
+		enc.pending = &pendingCallback{key: key, fn: func() interface{} {
+			return fn()
+		}}
+	}
+	var zero R
+	return zero, errPendingCallback
+}
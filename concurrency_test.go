@@ -0,0 +1,276 @@
+package gql_auto_test
+
+import (
+	"fmt"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type ConcurrentNode struct {
+	Name     string            `graphql:"!name"`
+	Parent   *ConcurrentNode   `graphql:"parent"`
+	Children []*ConcurrentNode `graphql:"children"`
+	Tenant   ConcurrentTenant  `graphql:"tenant"`
+}
+
+type ConcurrentTenant struct {
+	ID    string           `graphql:"!id"`
+	Nodes []ConcurrentNode `graphql:"nodes"`
+}
+
+type ConcurrentArgs struct {
+	Node ConcurrentNode `graphql:"!node"`
+}
+
+func TestEncoder_Concurrent(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+
+	const workers = 16
+	wg := sync.WaitGroup{}
+	errs := make(chan error, workers)
+	objects := make(chan *graphql.Object, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			schema, err := enc.SchemaBuilder().
+				Query("node", ConcurrentArgs{}, ConcurrentNode{}, func(p graphql.ResolveParams) (interface{}, error) {
+					var args ConcurrentArgs
+					err := enc.DecodeArgs(p, &args)
+					return args.Node, err
+				}).
+				Query("tenants", nil, []ConcurrentTenant{}, nil).
+				Build()
+			if err != nil {
+				errs <- err
+				return
+			}
+			res := graphql.Do(graphql.Params{
+				Schema:        schema,
+				RequestString: fmt.Sprintf(`{ node(node: {name: "n%d", parent: {name: "p"}}) { name parent { name } } }`, i),
+			})
+			if len(res.Errors) > 0 {
+				errs <- res.Errors[0]
+				return
+			}
+			obj, err := enc.StructOf(reflect.TypeOf(ConcurrentTenant{}))
+			if err != nil {
+				errs <- err
+				return
+			}
+			_ = enc.PrintSDL()
+			objects <- obj
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	close(objects)
+
+	for err := range errs {
+		ass.NoError(err)
+	}
+	var first *graphql.Object
+	for obj := range objects {
+		if first == nil {
+			first = obj
+		}
+		ass.Same(first, obj)
+	}
+	ass.Len(first.Fields(), 2)
+}
+
+func TestEncoder_ConcurrentAddField(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type AddFieldConcurrent struct {
+		Name string `graphql:"name"`
+	}
+
+	enc := gql_auto.NewEncoder()
+	obj, err := enc.Struct(AddFieldConcurrent{})
+	ass.NoError(err)
+
+	const workers = 16
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			enc.AddField(obj, &graphql.Field{Name: fmt.Sprintf("extra%d", i), Type: graphql.String})
+		}(i)
+	}
+	wg.Wait()
+
+	ass.Len(obj.Fields(), 1+workers)
+}
+
+func TestAddField(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type AddFieldExample struct {
+		Name string `graphql:"name"`
+	}
+
+	obj := gql_auto.Struct(AddFieldExample{})
+	gql_auto.AddField(obj, &graphql.Field{Name: "extra", Type: graphql.Int})
+	ass.Contains(obj.Fields(), "name")
+	ass.Contains(obj.Fields(), "extra")
+}
+
+type ReentrantInner struct {
+	Name string `graphql:"name"`
+}
+
+type ReentrantWrapper struct{}
+
+// GraphqlType builds another type with the encoder that is building the
+// wrapper.
+func (*ReentrantWrapper) GraphqlType() graphql.Type {
+	return gql_auto.Struct(ReentrantInner{})
+}
+
+type ReentrantOuter struct {
+	Wrapper  ReentrantWrapper   `graphql:"wrapper"`
+	Wrappers []ReentrantWrapper `graphql:"wrappers"`
+}
+
+func TestEncoder_ReentrantGraphqlType(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	done := make(chan *graphql.Object)
+	go func() {
+		done <- gql_auto.Struct(ReentrantOuter{})
+	}()
+	select {
+	case obj := <-done:
+		fields := obj.Fields()
+		ass.Equal("ReentrantInner", fields["wrapper"].Type.String())
+		ass.Equal("[ReentrantInner]", fields["wrappers"].Type.String())
+	case <-time.After(2 * time.Second):
+		t.Fatal("the encoder is locked by GraphqlType")
+	}
+}
+
+func TestEncoder_ConcurrentDecode(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	_, err := enc.PatchInputOf(reflect.TypeOf(FilterUser{}))
+	ass.NoError(err)
+
+	const workers = 16
+	wg := sync.WaitGroup{}
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// The filter arguments are built by the first decoder.
+			args, err := enc.DecodeFilterArgs(graphql.ResolveParams{Args: map[string]interface{}{
+				"filter": map[string]interface{}{"age": map[string]interface{}{"gt": i}},
+				"limit":  i,
+			}}, reflect.TypeOf(FilterUser{}))
+			ass.NoError(err)
+			ass.Equal(i, args.Filter.Conditions[0].Value)
+
+			patch, err := gql_auto.DecodePatch[FilterUser](enc, map[string]interface{}{"age": i})
+			ass.NoError(err)
+			ass.Equal(i, patch.Value.Age)
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestEncoder_ConcurrentRawSchemas(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	shape, err := enc.RegisterInterface(
+		reflect.TypeOf((*AbstractShape)(nil)).Elem(),
+		reflect.TypeOf(AbstractCircle{}),
+		reflect.TypeOf(AbstractSquare{}),
+	)
+	ass.NoError(err)
+	circle, err := enc.Struct(AbstractCircle{})
+	ass.NoError(err)
+	connection, err := enc.ConnectionOf(reflect.TypeOf(ConcurrentNode{}))
+	ass.NoError(err)
+
+	// The objects built by the encoder are shared by schemas built in
+	// parallel with graphql-go.
+	const workers = 16
+	wg := sync.WaitGroup{}
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := graphql.NewSchema(graphql.SchemaConfig{
+				Query: graphql.NewObject(graphql.ObjectConfig{
+					Name: "Query",
+					Fields: graphql.Fields{
+						"circle": &graphql.Field{Type: circle},
+						"shape":  &graphql.Field{Type: shape},
+						"nodes":  &graphql.Field{Type: connection},
+					},
+				}),
+			})
+			if err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		ass.NoError(err)
+	}
+}
+
+// reentrantEncoder is the encoder used by `ReentrantText`.
+var reentrantEncoder = gql_auto.NewEncoder()
+
+// ReentrantText builds a type with the encoder that is decoding it.
+type ReentrantText struct {
+	Value string
+}
+
+func (r *ReentrantText) UnmarshalText(text []byte) error {
+	_, err := reentrantEncoder.Struct(ReentrantInner{})
+	r.Value = string(text)
+	return err
+}
+
+type ReentrantArgs struct {
+	Text ReentrantText `graphql:"text"`
+}
+
+func TestEncoder_ReentrantUnmarshalText(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	done := make(chan error)
+	var args ReentrantArgs
+	go func() {
+		done <- reentrantEncoder.Decode(map[string]interface{}{"text": "value"}, &args)
+	}()
+	select {
+	case err := <-done:
+		ass.NoError(err)
+		ass.Equal("value", args.Text.Value)
+	case <-time.After(2 * time.Second):
+		t.Fatal("the encoder is locked by UnmarshalText")
+	}
+}
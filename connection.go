@@ -0,0 +1,280 @@
+package gql_auto
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Connection is a page of nodes in the shape of the Relay cursor
+// connections. It is represented by the "<Node>Connection" object built by
+// `ConnectionOf`, so it can be returned by the resolvers:
+//
+// ```
+//
+//	schema, err := enc.SchemaBuilder().
+//	    Query("users", UsersArgs{}, gql_auto.Connection[User]{}, func(p graphql.ResolveParams) (interface{}, error) {
+//	        var args UsersArgs
+//	        if err := enc.DecodeArgs(p, &args); err != nil {
+//	            return nil, err
+//	        }
+//	        return gql_auto.ConnectionFromSlice(users, args.ConnectionArgs)
+//	    }).
+//	    Build()
+//
+// ```
+type Connection[T any] struct {
+	Edges      []Edge[T] `graphql:"edges"`
+	PageInfo   PageInfo  `graphql:"pageInfo"`
+	TotalCount int       `graphql:"totalCount"`
+}
+
+func (Connection[T]) connectionNode() reflect.Type {
+	return reflect.TypeOf((*T)(nil)).Elem()
+}
+
+// Edge is a node of a `Connection` with its cursor.
+type Edge[T any] struct {
+	Node   T      `graphql:"node"`
+	Cursor string `graphql:"cursor"`
+}
+
+// PageInfo describes the page of a `Connection`.
+type PageInfo struct {
+	HasNextPage     bool    `graphql:"!hasNextPage"`
+	HasPreviousPage bool    `graphql:"!hasPreviousPage"`
+	StartCursor     *string `graphql:"startCursor"`
+	EndCursor       *string `graphql:"endCursor"`
+}
+
+// ConnectionArgs are the arguments of the fields that return connections.
+// They are usually embedded in the arguments of the field:
+//
+// ```
+//
+//	type UsersArgs struct {
+//	    gql_auto.ConnectionArgs
+//	    Role string `graphql:"role"`
+//	}
+//
+// ```
+type ConnectionArgs struct {
+	// First is the number of nodes after the cursor After.
+	First *int    `graphql:"first"`
+	After *string `graphql:"after"`
+	// Last is the number of nodes before the cursor Before.
+	Last   *int    `graphql:"last"`
+	Before *string `graphql:"before"`
+}
+
+// CursorProvider is the interface implemented by the nodes that have their
+// own cursors, i.e. their IDs, so the cursors do not change when the nodes
+// before them are added or removed.
+type CursorProvider interface {
+	GraphqlCursor() string
+}
+
+// connection is implemented by every `Connection`.
+type connection interface {
+	connectionNode() reflect.Type
+}
+
+var (
+	connectionType = reflect.TypeOf(new(connection)).Elem()
+	pageInfoType   = reflect.TypeOf(PageInfo{})
+)
+
+// isConnection checks if t is a `Connection`.
+func isConnection(t reflect.Type) bool {
+	return reflect.PtrTo(t).Implements(connectionType)
+}
+
+// ConnectionOf returns the Relay connection object of the nodes of the struct
+// t, named "<Node>Connection", with the fields:
+//
+// * edges: The list of "<Node>Edge" objects, with the node and its cursor;
+// * pageInfo: The `PageInfo` of the page;
+// * totalCount: The number of nodes of all the pages.
+//
+// The objects are represented by the `Connection` type.
+func (enc *Encoder) ConnectionOf(t reflect.Type) (*graphql.Object, error) {
+	return build(enc, func() (*graphql.Object, error) {
+		return enc.connectionOf(t)
+	})
+}
+
+func (enc *Encoder) connectionOf(t reflect.Type) (*graphql.Object, error) {
+	t = cacheKey(t)
+	if r, ok := enc.connections[t]; ok {
+		return r, nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot build a connection of %s, it is not a struct", t)
+	}
+
+	node, err := enc.structOf(t)
+	if err != nil {
+		return nil, err
+	}
+	pageInfo, err := enc.structOf(pageInfoType)
+	if err != nil {
+		return nil, err
+	}
+
+	edgeName := node.Name() + "Edge"
+	connectionName := node.Name() + "Connection"
+	for _, name := range []string{edgeName, connectionName} {
+		if err := enc.claimName(name, t); err != nil {
+			return nil, err
+		}
+	}
+
+	// The fields are read by the default resolver, which matches the tags
+	// of `Connection` and `Edge` with any type of node.
+	edge := enc.newObject(graphql.ObjectConfig{
+		Name:        edgeName,
+		Description: fmt.Sprintf("An edge of a %s.", connectionName),
+		Fields: graphql.Fields{
+			"node": &graphql.Field{
+				Type:        node,
+				Description: "The node of the edge.",
+			},
+			"cursor": &graphql.Field{
+				Type:        graphql.NewNonNull(graphql.String),
+				Description: "The cursor of the node.",
+			},
+		},
+	})
+	r := enc.newObject(graphql.ObjectConfig{
+		Name:        connectionName,
+		Description: fmt.Sprintf("A page of %s nodes.", node.Name()),
+		Fields: graphql.Fields{
+			"edges": &graphql.Field{
+				Type:        graphql.NewList(edge),
+				Description: "The edges of the page.",
+			},
+			"pageInfo": &graphql.Field{
+				Type:        graphql.NewNonNull(pageInfo),
+				Description: "The information of the page.",
+			},
+			"totalCount": &graphql.Field{
+				Type:        graphql.Int,
+				Description: "The number of nodes of all the pages.",
+			},
+		},
+	})
+	freeze(edge, r)
+
+	setCache(enc, enc.connections, t, r)
+	return r, nil
+}
+
+// ConnectionFromSlice returns the page of the nodes selected by the
+// arguments.
+//
+// The cursors are opaque base64 strings. They are the offsets of the nodes in
+// the slice, unless the nodes implement `CursorProvider`, in which case they
+// are the cursors of the nodes.
+func ConnectionFromSlice[T any](nodes []T, args ConnectionArgs) (Connection[T], error) {
+	start, end := 0, len(nodes)
+	if args.After != nil {
+		i, err := cursorIndex(nodes, *args.After)
+		if err != nil {
+			return Connection[T]{}, fmt.Errorf("after: %w", err)
+		}
+		start = max(start, i+1)
+	}
+	if args.Before != nil {
+		i, err := cursorIndex(nodes, *args.Before)
+		if err != nil {
+			return Connection[T]{}, fmt.Errorf("before: %w", err)
+		}
+		end = min(end, i)
+	}
+	if end < start {
+		end = start
+	}
+	if args.First != nil {
+		if *args.First < 0 {
+			return Connection[T]{}, errors.New("first cannot be negative")
+		}
+		end = min(end, start+*args.First)
+	}
+	if args.Last != nil {
+		if *args.Last < 0 {
+			return Connection[T]{}, errors.New("last cannot be negative")
+		}
+		start = max(start, end-*args.Last)
+	}
+
+	r := Connection[T]{
+		Edges:      make([]Edge[T], 0, end-start),
+		TotalCount: len(nodes),
+		PageInfo: PageInfo{
+			HasPreviousPage: start > 0,
+			HasNextPage:     end < len(nodes),
+		},
+	}
+	for i := start; i < end; i++ {
+		r.Edges = append(r.Edges, Edge[T]{Node: nodes[i], Cursor: nodeCursor(nodes, i)})
+	}
+	if len(r.Edges) > 0 {
+		r.PageInfo.StartCursor = &r.Edges[0].Cursor
+		r.PageInfo.EndCursor = &r.Edges[len(r.Edges)-1].Cursor
+	}
+	return r, nil
+}
+
+const (
+	offsetCursorPrefix = "offset:"
+	nodeCursorPrefix   = "cursor:"
+)
+
+// nodeCursor returns the cursor of the node i.
+func nodeCursor[T any](nodes []T, i int) string {
+	if c, ok := any(nodes[i]).(CursorProvider); ok {
+		return encodeCursor(nodeCursorPrefix + c.GraphqlCursor())
+	}
+	return encodeCursor(offsetCursorPrefix + strconv.Itoa(i))
+}
+
+// cursorIndex returns the index of the node of the cursor.
+func cursorIndex[T any](nodes []T, cursor string) (int, error) {
+	s, err := decodeCursor(cursor)
+	if err != nil {
+		return 0, err
+	}
+	if offset, ok := strings.CutPrefix(s, offsetCursorPrefix); ok {
+		i, err := strconv.Atoi(offset)
+		if err != nil || i < 0 {
+			return 0, fmt.Errorf("invalid cursor '%s'", cursor)
+		}
+		return i, nil
+	}
+	if key, ok := strings.CutPrefix(s, nodeCursorPrefix); ok {
+		for i := range nodes {
+			if c, ok := any(nodes[i]).(CursorProvider); ok && c.GraphqlCursor() == key {
+				return i, nil
+			}
+		}
+		return 0, fmt.Errorf("unknown cursor '%s'", cursor)
+	}
+	return 0, fmt.Errorf("invalid cursor '%s'", cursor)
+}
+
+func encodeCursor(s string) string {
+	return base64.StdEncoding.EncodeToString([]byte(s))
+}
+
+func decodeCursor(cursor string) (string, error) {
+	b, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return "", fmt.Errorf("invalid cursor '%s'", cursor)
+	}
+	return string(b), nil
+}
@@ -0,0 +1,190 @@
+package gql_auto_test
+
+import (
+	"encoding/base64"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type ConnectionUser struct {
+	ID   string `graphql:"id"`
+	Name string `graphql:"name"`
+}
+
+// ConnectionKeyed has its own cursors.
+type ConnectionKeyed struct {
+	ID string `graphql:"id"`
+}
+
+func (k ConnectionKeyed) GraphqlCursor() string {
+	return k.ID
+}
+
+type ConnectionUsersArgs struct {
+	gql_auto.ConnectionArgs
+	Prefix string `graphql:"prefix"`
+}
+
+var connectionUsers = []*ConnectionUser{
+	{ID: "1", Name: "Ada"},
+	{ID: "2", Name: "Grace"},
+	{ID: "3", Name: "Barbara"},
+	{ID: "4", Name: "Frances"},
+	{ID: "5", Name: "Radia"},
+}
+
+func intPtr(n int) *int {
+	return &n
+}
+
+func stringPtr(s string) *string {
+	return &s
+}
+
+func TestEncoder_ConnectionOf(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	obj, err := enc.ConnectionOf(reflect.TypeOf(ConnectionUser{}))
+	ass.NoError(err)
+	ass.Equal("ConnectionUserConnection", obj.Name())
+	fields := obj.Fields()
+	ass.Equal("[ConnectionUserEdge]", fields["edges"].Type.String())
+	ass.Equal("PageInfo!", fields["pageInfo"].Type.String())
+	ass.Equal("Int", fields["totalCount"].Type.String())
+
+	edge := fields["edges"].Type.(*graphql.List).OfType.(*graphql.Object)
+	ass.Equal("ConnectionUser", edge.Fields()["node"].Type.String())
+	ass.Equal("String!", edge.Fields()["cursor"].Type.String())
+
+	pageInfo := fields["pageInfo"].Type.(*graphql.NonNull).OfType.(*graphql.Object)
+	ass.Equal("Boolean!", pageInfo.Fields()["hasNextPage"].Type.String())
+	ass.Equal("String", pageInfo.Fields()["endCursor"].Type.String())
+
+	// The connections are listed by the types of the encoder.
+	ass.Contains(enc.Types(), graphql.Type(obj))
+	ass.Contains(enc.PrintSDL(), "type ConnectionUserConnection {")
+	ass.Contains(enc.PrintSDL(), "type ConnectionUserEdge {")
+
+	// The pointers and the Connection type share the object.
+	same, err := enc.ConnectionOf(reflect.TypeOf(&ConnectionUser{}))
+	ass.NoError(err)
+	ass.Same(obj, same)
+	same2, err := enc.TypeOf(reflect.TypeOf(gql_auto.Connection[*ConnectionUser]{}))
+	ass.NoError(err)
+	ass.Same(obj, same2)
+
+	// The lists of connections are lists of the connection objects.
+	list, err := gql_auto.NewEncoder().TypeOf(reflect.TypeOf([]gql_auto.Connection[ConnectionUser]{}))
+	ass.NoError(err)
+	ass.Equal("[ConnectionUserConnection]", list.String())
+
+	args, err := enc.Args(ConnectionUsersArgs{})
+	ass.NoError(err)
+	ass.Equal("Int", args["first"].Type.String())
+	ass.Equal("String", args["after"].Type.String())
+	ass.Equal("Int", args["last"].Type.String())
+	ass.Equal("String", args["before"].Type.String())
+	ass.Equal("String", args["prefix"].Type.String())
+
+	_, err = enc.ConnectionOf(reflect.TypeOf(""))
+	ass.Error(err)
+}
+
+func TestConnectionFromSlice(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	c, err := gql_auto.ConnectionFromSlice(connectionUsers, gql_auto.ConnectionArgs{First: intPtr(2)})
+	ass.NoError(err)
+	ass.Len(c.Edges, 2)
+	ass.Equal("Ada", c.Edges[0].Node.Name)
+	ass.Equal(5, c.TotalCount)
+	ass.True(c.PageInfo.HasNextPage)
+	ass.False(c.PageInfo.HasPreviousPage)
+	ass.Equal(base64.StdEncoding.EncodeToString([]byte("offset:1")), *c.PageInfo.EndCursor)
+
+	c, err = gql_auto.ConnectionFromSlice(connectionUsers, gql_auto.ConnectionArgs{First: intPtr(2), After: c.PageInfo.EndCursor})
+	ass.NoError(err)
+	ass.Equal([]string{"Barbara", "Frances"}, []string{c.Edges[0].Node.Name, c.Edges[1].Node.Name})
+	ass.True(c.PageInfo.HasNextPage)
+	ass.True(c.PageInfo.HasPreviousPage)
+
+	c, err = gql_auto.ConnectionFromSlice(connectionUsers, gql_auto.ConnectionArgs{Last: intPtr(2), Before: c.PageInfo.EndCursor})
+	ass.NoError(err)
+	ass.Equal([]string{"Grace", "Barbara"}, []string{c.Edges[0].Node.Name, c.Edges[1].Node.Name})
+
+	c, err = gql_auto.ConnectionFromSlice(connectionUsers, gql_auto.ConnectionArgs{Last: intPtr(1)})
+	ass.NoError(err)
+	ass.Equal("Radia", c.Edges[0].Node.Name)
+	ass.False(c.PageInfo.HasNextPage)
+	ass.True(c.PageInfo.HasPreviousPage)
+
+	c, err = gql_auto.ConnectionFromSlice([]*ConnectionUser{}, gql_auto.ConnectionArgs{})
+	ass.NoError(err)
+	ass.Empty(c.Edges)
+	ass.Nil(c.PageInfo.StartCursor)
+
+	keyed := []ConnectionKeyed{{ID: "a"}, {ID: "b"}, {ID: "c"}}
+	k, err := gql_auto.ConnectionFromSlice(keyed, gql_auto.ConnectionArgs{First: intPtr(1)})
+	ass.NoError(err)
+	ass.Equal(base64.StdEncoding.EncodeToString([]byte("cursor:a")), k.Edges[0].Cursor)
+	k, err = gql_auto.ConnectionFromSlice(keyed, gql_auto.ConnectionArgs{After: &k.Edges[0].Cursor})
+	ass.NoError(err)
+	ass.Equal([]ConnectionKeyed{{ID: "b"}, {ID: "c"}}, []ConnectionKeyed{k.Edges[0].Node, k.Edges[1].Node})
+
+	_, err = gql_auto.ConnectionFromSlice(connectionUsers, gql_auto.ConnectionArgs{First: intPtr(-1)})
+	ass.EqualError(err, "first cannot be negative")
+	_, err = gql_auto.ConnectionFromSlice(connectionUsers, gql_auto.ConnectionArgs{After: stringPtr("!")})
+	ass.EqualError(err, "after: invalid cursor '!'")
+	_, err = gql_auto.ConnectionFromSlice(keyed, gql_auto.ConnectionArgs{Before: stringPtr(base64.StdEncoding.EncodeToString([]byte("cursor:z")))})
+	ass.ErrorContains(err, "before: unknown cursor")
+}
+
+func TestSchemaBuilder_Connection(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	schema, err := enc.SchemaBuilder().
+		Query("users", ConnectionUsersArgs{}, gql_auto.Connection[*ConnectionUser]{}, func(p graphql.ResolveParams) (interface{}, error) {
+			var args ConnectionUsersArgs
+			if err := enc.DecodeArgs(p, &args); err != nil {
+				return nil, err
+			}
+			return gql_auto.ConnectionFromSlice(connectionUsers, args.ConnectionArgs)
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			users(first: 2, after: "b2Zmc2V0OjA=") {
+				totalCount
+				edges { cursor node { id name } }
+				pageInfo { hasNextPage hasPreviousPage startCursor endCursor }
+			}
+		}`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"users": map[string]interface{}{
+			"totalCount": 5,
+			"edges": []interface{}{
+				map[string]interface{}{"cursor": "b2Zmc2V0OjE=", "node": map[string]interface{}{"id": "2", "name": "Grace"}},
+				map[string]interface{}{"cursor": "b2Zmc2V0OjI=", "node": map[string]interface{}{"id": "3", "name": "Barbara"}},
+			},
+			"pageInfo": map[string]interface{}{
+				"hasNextPage":     true,
+				"hasPreviousPage": true,
+				"startCursor":     "b2Zmc2V0OjE=",
+				"endCursor":       "b2Zmc2V0OjI=",
+			},
+		},
+	}, res.Data)
+}
@@ -0,0 +1,238 @@
+package gql_auto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Repository stores the values of a model for the fields generated by
+// `CRUD`. The values are identified by the IDs received by the fields.
+type Repository[T any] interface {
+	// Get returns the value of the ID, or nil when there is none.
+	Get(ctx context.Context, id string) (*T, error)
+	// List returns the values selected by the arguments.
+	List(ctx context.Context, args FilterArgs) ([]T, error)
+	// Create stores a new value and returns it as stored, i.e. with its ID.
+	Create(ctx context.Context, value T) (*T, error)
+	// Update sets the fields of the patch in the value of the ID, and
+	// returns it. It returns nil when there is no value with the ID.
+	Update(ctx context.Context, id string, patch Patch[T]) (*T, error)
+	// Delete removes the value of the ID, and reports whether it existed.
+	Delete(ctx context.Context, id string) (bool, error)
+}
+
+// CRUDFields are the fields of a model added to a schema by
+// `SchemaBuilder.CRUD`.
+type CRUDFields interface {
+	addTo(b *SchemaBuilder) error
+}
+
+type crudFields[T any] struct {
+	repo  Repository[T]
+	names crudNames
+}
+
+// crudNames are the names of the query fields generated by `CRUD`. They are
+// derived from the name of the object when they are empty.
+type crudNames struct {
+	single string
+	list   string
+}
+
+// CRUDOption configures the fields generated by `CRUD`.
+type CRUDOption func(names *crudNames)
+
+// WithCRUDName sets the name of the query field that returns a value, i.e.
+// person. It defaults to the name of the object in lower camel case.
+func WithCRUDName(name string) CRUDOption {
+	return func(names *crudNames) {
+		names.single = name
+	}
+}
+
+// WithCRUDListName sets the name of the query field that lists the values,
+// i.e. people. It defaults to the name of the value field followed by "s",
+// which does not fit the irregular plurals, like addresses or categories.
+//
+// ```
+//
+//	gql_auto.CRUD[Category](repo, gql_auto.WithCRUDListName("categories"))
+//
+// ```
+func WithCRUDListName(name string) CRUDOption {
+	return func(names *crudNames) {
+		names.list = name
+	}
+}
+
+// CRUD returns the fields that read and write the values of the struct T
+// stored by repo. For a struct Person, they are:
+//
+// * person(id: ID!): Person, the value of the ID;
+// * persons(filter, orderBy, limit, offset): [Person], the values selected by
+// the arguments of `FilterArgsOf`;
+// * createPerson(input: PersonInput!): Person, creates a value from the input
+// object of `InputObjectOf`;
+// * updatePerson(id: ID!, input: PersonPatchInput!): Person, updates the
+// fields informed by the input object of `PatchInputOf`;
+// * deletePerson(id: ID!): Boolean!, deletes the value of the ID.
+//
+// The names of the query fields are set by the options, i.e.
+// `WithCRUDListName`. The fields are added to a schema by
+// `SchemaBuilder.CRUD`:
+//
+// ```
+//
+//	schema, err := enc.SchemaBuilder().
+//	    CRUD(gql_auto.CRUD[Person](repo)).
+//	    Build()
+//
+// ```
+func CRUD[T any](repo Repository[T], options ...CRUDOption) CRUDFields {
+	r := crudFields[T]{repo: repo}
+	for _, option := range options {
+		option(&r.names)
+	}
+	return r
+}
+
+// CRUD adds the query and mutation fields of a model built by `CRUD`.
+func (b *SchemaBuilder) CRUD(fields CRUDFields) *SchemaBuilder {
+	err := fields.addTo(b)
+	if err != nil {
+		b.errs = append(b.errs, err)
+	}
+	return b
+}
+
+func (c crudFields[T]) addTo(b *SchemaBuilder) error {
+	enc := b.encoder
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return fmt.Errorf("cannot build the CRUD fields of %s, it is not a struct", t)
+	}
+
+	obj, err := enc.StructOf(t)
+	if err != nil {
+		return err
+	}
+	list, err := enc.TypeOf(reflect.SliceOf(t))
+	if err != nil {
+		return err
+	}
+	filterArgs, err := enc.FilterArgsOf(t)
+	if err != nil {
+		return err
+	}
+	input, err := enc.InputObjectOf(t)
+	if err != nil {
+		return err
+	}
+	patchInput, err := enc.PatchInputOf(t)
+	if err != nil {
+		return err
+	}
+
+	name := c.names.single
+	if name == "" {
+		name = toLowerCamelCase(obj.Name())
+	}
+	listName := c.names.list
+	if listName == "" {
+		listName = name + "s"
+	}
+	idArg := &graphql.ArgumentConfig{
+		Type:        graphql.NewNonNull(graphql.ID),
+		Description: fmt.Sprintf("The ID of the %s.", obj.Name()),
+	}
+
+	b.QueryField(name, graphql.Field{
+		Name:        name,
+		Type:        obj,
+		Description: fmt.Sprintf("Returns the %s of the ID.", obj.Name()),
+		Args:        graphql.FieldConfigArgument{"id": idArg},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return nilIfNone(c.repo.Get(resolveContext(p), fmt.Sprint(p.Args["id"])))
+		},
+	})
+	b.QueryField(listName, graphql.Field{
+		Name:        listName,
+		Type:        list,
+		Description: fmt.Sprintf("Returns the %s values selected by the arguments.", obj.Name()),
+		Args:        filterArgs,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			args, err := enc.DecodeFilterArgs(p, t)
+			if err != nil {
+				return nil, err
+			}
+			return c.repo.List(resolveContext(p), args)
+		},
+	})
+
+	create := "create" + obj.Name()
+	b.MutationField(create, graphql.Field{
+		Name:        create,
+		Type:        obj,
+		Description: fmt.Sprintf("Creates a %s.", obj.Name()),
+		Args: graphql.FieldConfigArgument{
+			"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			var value T
+			err := enc.Decode(p.Args["input"], &value)
+			if err != nil {
+				return nil, err
+			}
+			return nilIfNone(c.repo.Create(resolveContext(p), value))
+		},
+	})
+	update := "update" + obj.Name()
+	b.MutationField(update, graphql.Field{
+		Name:        update,
+		Type:        obj,
+		Description: fmt.Sprintf("Updates the fields informed of the %s of the ID.", obj.Name()),
+		Args: graphql.FieldConfigArgument{
+			"id":    idArg,
+			"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(patchInput)},
+		},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			patch, err := DecodePatchArg[T](enc, p, "input")
+			if err != nil {
+				return nil, err
+			}
+			return nilIfNone(c.repo.Update(resolveContext(p), fmt.Sprint(p.Args["id"]), patch))
+		},
+	})
+	remove := "delete" + obj.Name()
+	b.MutationField(remove, graphql.Field{
+		Name:        remove,
+		Type:        graphql.NewNonNull(graphql.Boolean),
+		Description: fmt.Sprintf("Deletes the %s of the ID, it returns false when there is none.", obj.Name()),
+		Args:        graphql.FieldConfigArgument{"id": idArg},
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			return c.repo.Delete(resolveContext(p), fmt.Sprint(p.Args["id"]))
+		},
+	})
+	return nil
+}
+
+// resolveContext returns the context of the resolver, or the background
+// context when there is none.
+func resolveContext(p graphql.ResolveParams) context.Context {
+	if p.Context == nil {
+		return context.Background()
+	}
+	return p.Context
+}
+
+// nilIfNone returns a nil interface for the nil pointers, which are
+// represented by null.
+func nilIfNone[T any](v *T, err error) (interface{}, error) {
+	if err != nil || v == nil {
+		return nil, err
+	}
+	return v, nil
+}
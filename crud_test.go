@@ -0,0 +1,205 @@
+package gql_auto_test
+
+import (
+	"context"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+type CRUDPerson struct {
+	ID       string  `graphql:"id"`
+	Name     string  `graphql:"!name"`
+	Age      int     `graphql:"age"`
+	Nickname *string `graphql:"nickname"`
+}
+
+// crudPeople is a minimal repository of people, the filters are ignored.
+type crudPeople struct {
+	mu     sync.Mutex
+	people []CRUDPerson
+	lastID int
+}
+
+func (r *crudPeople) index(id string) int {
+	for i, p := range r.people {
+		if p.ID == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *crudPeople) Get(_ context.Context, id string) (*CRUDPerson, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if i := r.index(id); i >= 0 {
+		p := r.people[i]
+		return &p, nil
+	}
+	return nil, nil
+}
+
+func (r *crudPeople) List(_ context.Context, args gql_auto.FilterArgs) ([]CRUDPerson, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	people := append([]CRUDPerson{}, r.people...)
+	if args.Limit != nil && *args.Limit < len(people) {
+		people = people[:*args.Limit]
+	}
+	return people, nil
+}
+
+func (r *crudPeople) Create(_ context.Context, value CRUDPerson) (*CRUDPerson, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastID++
+	value.ID = strconv.Itoa(r.lastID)
+	r.people = append(r.people, value)
+	return &value, nil
+}
+
+func (r *crudPeople) Update(_ context.Context, id string, patch gql_auto.Patch[CRUDPerson]) (*CRUDPerson, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.index(id)
+	if i < 0 {
+		return nil, nil
+	}
+	p := r.people[i]
+	err := patch.Apply(&p)
+	if err != nil {
+		return nil, err
+	}
+	r.people[i] = p
+	return &p, nil
+}
+
+func (r *crudPeople) Delete(_ context.Context, id string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	i := r.index(id)
+	if i < 0 {
+		return false, nil
+	}
+	r.people = append(r.people[:i], r.people[i+1:]...)
+	return true, nil
+}
+
+func TestSchemaBuilder_CRUD(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	schema, err := enc.SchemaBuilder().
+		CRUD(gql_auto.CRUD[CRUDPerson](&crudPeople{})).
+		Build()
+	ass.NoError(err)
+
+	query := schema.QueryType().Fields()
+	ass.Equal("CRUDPerson", query["crudPerson"].Type.String())
+	ass.Equal("[CRUDPerson]", query["crudPersons"].Type.String())
+	mutation := schema.MutationType().Fields()
+	ass.Equal("CRUDPerson", mutation["createCRUDPerson"].Type.String())
+	ass.Equal("CRUDPersonInput!", mutation["createCRUDPerson"].Args[0].Type.String())
+	ass.Equal("CRUDPerson", mutation["updateCRUDPerson"].Type.String())
+	ass.Equal("Boolean!", mutation["deleteCRUDPerson"].Type.String())
+
+	patch := schema.Type("CRUDPersonPatchInput").(*graphql.InputObject).Fields()
+	ass.Equal("String", patch["name"].Type.String())
+	ass.Equal("String", patch["id"].Type.String())
+	ass.Equal("Int", patch["age"].Type.String())
+
+	do := func(request string) map[string]interface{} {
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: request})
+		ass.Empty(res.Errors, request)
+		data, _ := res.Data.(map[string]interface{})
+		return data
+	}
+
+	ass.Equal(map[string]interface{}{
+		"createCRUDPerson": map[string]interface{}{"id": "1", "name": "Ada", "age": 36, "nickname": "Countess"},
+	}, do(`mutation { createCRUDPerson(input: {name: "Ada", age: 36, nickname: "Countess"}) { id name age nickname } }`))
+	do(`mutation { createCRUDPerson(input: {name: "Grace"}) { id } }`)
+
+	// The fields omitted keep their values.
+	ass.Equal(map[string]interface{}{
+		"updateCRUDPerson": map[string]interface{}{"id": "1", "name": "Ada Lovelace", "age": 36, "nickname": "Countess"},
+	}, do(`mutation { updateCRUDPerson(id: "1", input: {name: "Ada Lovelace"}) { id name age nickname } }`))
+	// The fields set to null are cleared.
+	variables := map[string]interface{}{"input": map[string]interface{}{"nickname": nil}}
+	res := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `mutation ($input: CRUDPersonPatchInput!) { updateCRUDPerson(id: "1", input: $input) { name nickname } }`,
+		VariableValues: variables,
+		Context:        gql_auto.ContextWithVariables(context.Background(), variables),
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"updateCRUDPerson": map[string]interface{}{"name": "Ada Lovelace", "nickname": nil},
+	}, res.Data)
+	ass.Equal(map[string]interface{}{
+		"updateCRUDPerson": nil,
+	}, do(`mutation { updateCRUDPerson(id: "3", input: {name: "Nobody"}) { id } }`))
+
+	ass.Equal(map[string]interface{}{
+		"crudPerson": map[string]interface{}{"name": "Ada Lovelace"},
+		"missing":    nil,
+	}, do(`{ crudPerson(id: 1) { name } missing: crudPerson(id: "3") { name } }`))
+	ass.Equal(map[string]interface{}{
+		"crudPersons": []interface{}{
+			map[string]interface{}{"id": "1"},
+			map[string]interface{}{"id": "2"},
+		},
+	}, do(`{ crudPersons(filter: {name: {contains: "a"}}) { id } }`))
+	ass.Equal(map[string]interface{}{
+		"crudPersons": []interface{}{map[string]interface{}{"id": "1"}},
+	}, do(`{ crudPersons(limit: 1) { id } }`))
+
+	ass.Equal(map[string]interface{}{
+		"deleteCRUDPerson": true,
+	}, do(`mutation { deleteCRUDPerson(id: "1") }`))
+	ass.Equal(map[string]interface{}{
+		"deleteCRUDPerson": false,
+	}, do(`mutation { deleteCRUDPerson(id: "1") }`))
+
+	res = graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation { createCRUDPerson(input: {age: 1}) { id } }`})
+	ass.Len(res.Errors, 1)
+
+	_, err = enc.SchemaBuilder().
+		CRUD(gql_auto.CRUD[string](nil)).
+		Build()
+	ass.Error(err)
+}
+
+func TestSchemaBuilder_CRUDNames(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	schema, err := enc.SchemaBuilder().
+		CRUD(gql_auto.CRUD[CRUDPerson](
+			&crudPeople{people: []CRUDPerson{{ID: "1", Name: "Ada"}}},
+			gql_auto.WithCRUDName("person"),
+			gql_auto.WithCRUDListName("people"),
+		)).
+		Build()
+	ass.NoError(err)
+
+	query := schema.QueryType().Fields()
+	ass.Contains(query, "person")
+	ass.Contains(query, "people")
+	ass.NotContains(query, "persons")
+	ass.NotContains(query, "crudPersons")
+	ass.Contains(schema.MutationType().Fields(), "createCRUDPerson")
+
+	res := graphql.Do(graphql.Params{Schema: schema, RequestString: `{ person(id: "1") { name } people { id } }`})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"person": map[string]interface{}{"name": "Ada"},
+		"people": []interface{}{map[string]interface{}{"id": "1"}},
+	}, res.Data)
+}
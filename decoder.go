@@ -0,0 +1,313 @@
+package gql_auto
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+)
+
+var textUnmarshalerType = reflect.TypeOf(new(encoding.TextUnmarshaler)).Elem()
+
+// DecodeArgs populates the struct pointed by dst with the arguments of the
+// resolver.
+//
+// The arguments are matched with the fields of the struct using the same
+// naming rules of `ArgsOf`, so the struct used to describe the arguments of
+// a field can be used to read them back.
+func (enc *Encoder) DecodeArgs(p graphql.ResolveParams, dst interface{}) error {
+	return enc.Decode(p.Args, dst)
+}
+
+// Decode populates the value pointed by dst with src. src is a value as
+// produced by graphql-go when parsing arguments: scalars, `[]interface{}`
+// for lists and `map[string]interface{}` for input objects.
+func (enc *Encoder) Decode(src interface{}, dst interface{}) error {
+	v := reflect.ValueOf(dst)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return fmt.Errorf("cannot decode into a non pointer")
+	}
+	return enc.decodeValue("", src, v.Elem())
+}
+
+// decodeValue sets dst with the value of src. path is the path of the value
+// from the root of the arguments, and it is used to report errors.
+func (enc *Encoder) decodeValue(path string, src interface{}, dst reflect.Value) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+
+	sv := reflect.ValueOf(src)
+	if sv.Type().AssignableTo(dst.Type()) {
+		dst.Set(sv)
+		return nil
+	}
+
+	if dst.Kind() == reflect.Ptr {
+		elem := reflect.New(dst.Type().Elem())
+		err := enc.decodeValue(path, src, elem.Elem())
+		if err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	decoded := enc.decodedTypeOf(dst.Type())
+	if decoded.nullWrapper {
+		return enc.decodeNullWrapper(path, src, dst)
+	}
+	if decoded.driverValuer {
+		return decodeSQLScanner(path, src, dst)
+	}
+
+	// Types like uuid.UUID are represented by strings.
+	if s, ok := src.(string); ok && reflect.PtrTo(dst.Type()).Implements(textUnmarshalerType) {
+		err := dst.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+		if err != nil {
+			return NewErrDecode(path, err)
+		}
+		return nil
+	}
+
+	if decoded.jsonUnmarshaler {
+		return decodeJSONUnmarshaler(path, src, dst)
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		m, ok := src.(map[string]interface{})
+		if !ok {
+			break
+		}
+		return enc.decodeStruct(path, m, dst)
+	case reflect.Map:
+		return enc.decodeMap(path, src, dst)
+	case reflect.Slice:
+		if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+			break
+		}
+		r := reflect.MakeSlice(dst.Type(), sv.Len(), sv.Len())
+		for i := 0; i < sv.Len(); i++ {
+			err := enc.decodeValue(indexPath(path, i), sv.Index(i).Interface(), r.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		dst.Set(r)
+		return nil
+	case reflect.Array:
+		if sv.Kind() != reflect.Slice && sv.Kind() != reflect.Array {
+			break
+		}
+		if sv.Len() > dst.Len() {
+			return NewErrDecode(path, fmt.Errorf("cannot decode %d elements into %s", sv.Len(), dst.Type()))
+		}
+		for i := 0; i < sv.Len(); i++ {
+			err := enc.decodeValue(indexPath(path, i), sv.Index(i).Interface(), dst.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case reflect.Bool:
+		if sv.Kind() != reflect.Bool {
+			break
+		}
+		dst.SetBool(sv.Bool())
+		return nil
+	case reflect.String:
+		if sv.Kind() != reflect.String {
+			break
+		}
+		dst.SetString(sv.String())
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		if sv.Kind() == reflect.String && enc.int64Policy == Int64AsString && isWideInt(dst.Kind()) {
+			return decodeStringOption(path, src, dst)
+		}
+		n, ok := toInt64(sv)
+		if !ok {
+			if _, ok := toUint64(sv); ok {
+				return NewErrDecode(path, errOverflow(src, dst.Type()))
+			}
+			break
+		}
+		if dst.OverflowInt(n) {
+			return NewErrDecode(path, errOverflow(src, dst.Type()))
+		}
+		dst.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if sv.Kind() == reflect.String && enc.int64Policy == Int64AsString && isWideInt(dst.Kind()) {
+			return decodeStringOption(path, src, dst)
+		}
+		n, ok := toUint64(sv)
+		if !ok {
+			break
+		}
+		if dst.OverflowUint(n) {
+			return NewErrDecode(path, errOverflow(src, dst.Type()))
+		}
+		dst.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		var f float64
+		switch sv.Kind() {
+		case reflect.Float32, reflect.Float64:
+			f = sv.Float()
+		default:
+			n, ok := toInt64(sv)
+			if !ok {
+				return NewErrDecode(path, fmt.Errorf("cannot decode %T into %s", src, dst.Type()))
+			}
+			f = float64(n)
+		}
+		if dst.OverflowFloat(f) {
+			return NewErrDecode(path, errOverflow(src, dst.Type()))
+		}
+		dst.SetFloat(f)
+		return nil
+	}
+
+	return NewErrDecode(path, fmt.Errorf("cannot decode %T into %s", src, dst.Type()))
+}
+
+// decodedType tells how the values of a Go type are decoded, as decided by
+// the types of the encoder.
+type decodedType struct {
+	nullWrapper     bool
+	driverValuer    bool
+	jsonUnmarshaler bool
+}
+
+// decodedTypeOf returns how the values of the type t are decoded. The types
+// of the encoder are read holding its lock, and the lock is released before
+// the values are decoded, so the unmarshalers of the users do not run
+// holding it.
+func (enc *Encoder) decodedTypeOf(t reflect.Type) decodedType {
+	if r, ok := enc.decodedTypes.Load(t); ok {
+		return r.(decodedType)
+	}
+	enc.mu.RLock()
+	defer enc.mu.RUnlock()
+	r := decodedType{
+		nullWrapper:     enc.isNullWrapper(t),
+		driverValuer:    enc.isDriverValuer(t),
+		jsonUnmarshaler: enc.isMarshaler(t) && reflect.PtrTo(t).Implements(jsonUnmarshalerType),
+	}
+	// It is stored holding the lock, so it is not stored after it is
+	// invalidated by a scalar registered concurrently.
+	enc.decodedTypes.Store(t, r)
+	return r
+}
+
+// decodeStruct sets the fields of the struct dst with the values of the input
+// object m.
+func (enc *Encoder) decodeStruct(path string, m map[string]interface{}, dst reflect.Value) error {
+	for _, sf := range enc.decodedFieldsOf(dst.Type()) {
+		value, ok := m[sf.name]
+		if !ok {
+			continue
+		}
+		err := enc.decodeField(dst, fieldPath(path, sf.name), sf, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// decodedField is a field of a struct decoded from an input object.
+type decodedField struct {
+	structField
+	// asString is true when the number is decoded from a string, by the
+	// "string" option of its tag.
+	asString bool
+}
+
+// decodedFieldsOf returns the fields of the struct t decoded from input
+// objects.
+func (enc *Encoder) decodedFieldsOf(t reflect.Type) []decodedField {
+	if r, ok := enc.decodedFields.Load(t); ok {
+		return r.([]decodedField)
+	}
+	fields := structFields(t, inputFieldName)
+	r := make([]decodedField, len(fields))
+	for i, sf := range fields {
+		tag, _, _ := inputFieldTag(sf.StructField)
+		r[i] = decodedField{structField: sf, asString: tag.asString && isStringOptionKind(sf.Type)}
+	}
+	enc.decodedFields.Store(t, r)
+	return r
+}
+
+// decodeField sets the field sf of the struct dst with value.
+func (enc *Encoder) decodeField(dst reflect.Value, path string, sf decodedField, value interface{}) error {
+	// The nil pointers to embedded structs are allocated only when one of
+	// their fields is set.
+	field, err := fieldByIndexAlloc(dst, sf.index)
+	if err != nil {
+		return NewErrDecode(path, err)
+	}
+	if sf.asString {
+		return decodeStringOption(path, value, field)
+	}
+	return enc.decodeValue(path, value, field)
+}
+
+// toInt64 converts numeric values to int64. Floats are only accepted when
+// they have no fractional part.
+func toInt64(v reflect.Value) (int64, bool) {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return 0, false
+		}
+		return int64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		if f != math.Trunc(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(f), true
+	}
+	return 0, false
+}
+
+// toUint64 converts non-negative numeric values to uint64. Floats are only
+// accepted when they have no fractional part.
+func toUint64(v reflect.Value) (uint64, bool) {
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint(), true
+	}
+	n, ok := toInt64(v)
+	if !ok || n < 0 {
+		return 0, false
+	}
+	return uint64(n), true
+}
+
+func fieldPath(path string, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func indexPath(path string, i int) string {
+	return path + "[" + strconv.Itoa(i) + "]"
+}
+
+// DecodeArgs populates the struct pointed by dst with the arguments of the
+// resolver using the `DefaultEncoder`.
+func DecodeArgs(p graphql.ResolveParams, dst interface{}) error {
+	return DefaultEncoder.DecodeArgs(p, dst)
+}
@@ -0,0 +1,127 @@
+package gql_auto_test
+
+import (
+	"errors"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type DecodeAddress struct {
+	Street string `graphql:"!street"`
+	Zip    int    `graphql:"zip"`
+}
+
+type DecodeArgs struct {
+	Name      string          `graphql:"!name"`
+	Nickname  *string         `graphql:"nickname"`
+	Age       int             `graphql:"age"`
+	Score     float64         `graphql:"score"`
+	Tags      []string        `graphql:"tags"`
+	Address   *DecodeAddress  `graphql:"address"`
+	Addresses []DecodeAddress `graphql:"addresses"`
+	UID       uuid.UUID       `graphql:"uid"`
+	Birthday  time.Time       `graphql:"birthday"`
+	Ignored   string          `graphql:"-"`
+}
+
+func TestEncoder_DecodeArgs(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	var decoded DecodeArgs
+	field, err := enc.Field(ThisIsAType{},
+		gql_auto.WithArgs(enc, DecodeArgs{}),
+		gql_auto.WithResolver(func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, &decoded)
+			return ThisIsAType{Name: decoded.Name}, err
+		}),
+	)
+	ass.NoError(err)
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: graphql.Fields{"person": &field},
+		}),
+	})
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			person(
+				name: "Snake Eyes",
+				nickname: "se",
+				age: 42,
+				score: 1.5,
+				tags: ["a", "b"],
+				address: {street: "Main", zip: 1234},
+				addresses: [{street: "First"}, {street: "Second", zip: 2}],
+				uid: "7b4b5b4c-7a36-4d3f-9b3a-2b5f9c1f3c1a",
+				birthday: "2020-01-02T03:04:05Z"
+			) { name }
+		}`,
+	})
+	ass.Empty(res.Errors)
+
+	ass.Equal("Snake Eyes", decoded.Name)
+	ass.Equal("se", *decoded.Nickname)
+	ass.Equal(42, decoded.Age)
+	ass.Equal(1.5, decoded.Score)
+	ass.Equal([]string{"a", "b"}, decoded.Tags)
+	ass.Equal(&DecodeAddress{Street: "Main", Zip: 1234}, decoded.Address)
+	ass.Equal([]DecodeAddress{{Street: "First"}, {Street: "Second", Zip: 2}}, decoded.Addresses)
+	ass.Equal(uuid.MustParse("7b4b5b4c-7a36-4d3f-9b3a-2b5f9c1f3c1a"), decoded.UID)
+	ass.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), decoded.Birthday)
+}
+
+func TestEncoder_DecodeError(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	args := map[string]interface{}{
+		"addresses": []interface{}{
+			map[string]interface{}{"street": "First"},
+			map[string]interface{}{"zip": "not a number"},
+		},
+	}
+
+	var decoded DecodeArgs
+	err := gql_auto.NewEncoder().Decode(args, &decoded)
+	ass.Error(err)
+	ass.ErrorContains(err, "addresses[1].zip")
+
+	var decodeErr *gql_auto.DecodeError
+	ass.True(errors.As(err, &decodeErr))
+	ass.Equal("addresses[1].zip", decodeErr.Path())
+}
+
+func TestEncoder_DecodeNonPointer(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	err := gql_auto.NewEncoder().Decode(map[string]interface{}{}, DecodeArgs{})
+	ass.Error(err)
+}
+
+func TestDecodeArgs(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	_, err := gql_auto.NewEncoder().ArgsOf(reflect.TypeOf(DecodeArgs{}))
+	ass.NoError(err)
+
+	var decoded DecodeArgs
+	err = gql_auto.DecodeArgs(graphql.ResolveParams{
+		Args: map[string]interface{}{"name": "Duke", "age": 7},
+	}, &decoded)
+	ass.NoError(err)
+	ass.Equal("Duke", decoded.Name)
+	ass.Equal(7, decoded.Age)
+}
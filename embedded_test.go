@@ -0,0 +1,160 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type EmbeddedBaseModel struct {
+	ID   string `graphql:"!id"`
+	Name string
+}
+
+type EmbeddedAudit struct {
+	CreatedBy string
+	Name      string
+}
+
+type EmbeddedOwner struct {
+	Name string
+}
+
+type EmbeddedDocument struct {
+	EmbeddedBaseModel
+	*EmbeddedAudit
+	Owner EmbeddedOwner `graphql:"owner"`
+	Title string
+}
+
+type EmbeddedShadowing struct {
+	EmbeddedBaseModel
+	EmbeddedAudit
+	Name string `graphql:"name"`
+}
+
+type EmbeddedAmbiguous struct {
+	EmbeddedAudit
+	EmbeddedOwner
+	ID string
+}
+
+type EmbeddedNamed struct {
+	EmbeddedOwner `graphql:"owner"`
+	ID            string
+}
+
+type EmbeddedIgnored struct {
+	EmbeddedOwner `json:"-"`
+	ID            string
+}
+
+func TestEncoder_StructOfEmbedded(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	obj, err := enc.Struct(EmbeddedDocument{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Len(fields, 4)
+	ass.Equal("String!", fields["id"].Type.String())
+	ass.Equal("String", fields["createdBy"].Type.String())
+	ass.Equal("EmbeddedOwner", fields["owner"].Type.String())
+	ass.Equal("String", fields["title"].Type.String())
+	// The name of the base model and of the audit are at the same depth
+	// without tags, so both are ignored.
+	ass.NotContains(fields, "name")
+	ass.NotContains(fields, "embeddedBaseModel")
+
+	obj, err = enc.Struct(EmbeddedShadowing{})
+	ass.NoError(err)
+	fields = obj.Fields()
+	ass.Len(fields, 3)
+	ass.Contains(fields, "id")
+	ass.Contains(fields, "name")
+	ass.Contains(fields, "createdBy")
+
+	obj, err = enc.Struct(EmbeddedAmbiguous{})
+	ass.NoError(err)
+	fields = obj.Fields()
+	ass.Len(fields, 2)
+	ass.Contains(fields, "createdBy")
+	ass.Contains(fields, "id")
+
+	obj, err = enc.Struct(EmbeddedNamed{})
+	ass.NoError(err)
+	fields = obj.Fields()
+	ass.Len(fields, 2)
+	ass.Equal("EmbeddedOwner", fields["owner"].Type.String())
+
+	obj, err = enc.Struct(EmbeddedIgnored{})
+	ass.NoError(err)
+	fields = obj.Fields()
+	ass.Len(fields, 1)
+	ass.Contains(fields, "id")
+}
+
+func TestEncoder_ArgsOfEmbedded(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	args, err := enc.ArgsOf(reflect.TypeOf(EmbeddedShadowing{}))
+	ass.NoError(err)
+	ass.Len(args, 3)
+	ass.Equal("String!", args["id"].Type.String())
+	ass.Contains(args, "name")
+	ass.Contains(args, "createdBy")
+
+	fields, err := enc.InputObjectFieldMap(reflect.TypeOf(EmbeddedDocument{}))
+	ass.NoError(err)
+	ass.Len(fields, 4)
+	ass.Contains(fields, "id")
+	ass.Contains(fields, "createdBy")
+	ass.Contains(fields, "owner")
+	ass.Contains(fields, "title")
+}
+
+func TestEncoder_ResolveEmbedded(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	var decoded EmbeddedDocument
+	schema, err := enc.SchemaBuilder().
+		Query("document", EmbeddedDocument{}, EmbeddedDocument{}, func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, &decoded)
+			return decoded, err
+		}).
+		Query("base", nil, EmbeddedDocument{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return &EmbeddedDocument{EmbeddedBaseModel: EmbeddedBaseModel{ID: "1"}}, nil
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			document(id: "1", createdBy: "duke", title: "t") { id createdBy title }
+			base { id createdBy }
+		}`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal("1", decoded.ID)
+	ass.NotNil(decoded.EmbeddedAudit)
+	ass.Equal("duke", decoded.CreatedBy)
+	ass.Equal(map[string]interface{}{
+		"document": map[string]interface{}{
+			"id":        "1",
+			"createdBy": "duke",
+			"title":     "t",
+		},
+		"base": map[string]interface{}{
+			"id":        "1",
+			"createdBy": nil,
+		},
+	}, res.Data)
+}
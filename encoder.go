@@ -3,20 +3,75 @@ package gql_auto
 import (
 	"fmt"
 	"reflect"
-	"strings"
+	"sort"
+	"sync"
 	"unicode"
 
 	"github.com/graphql-go/graphql"
 )
 
+// Encoder builds GraphQL types from Go types, caching every type built.
+//
+// An Encoder is safe for concurrent use by multiple goroutines. The types
+// are built while holding the lock of the encoder, so the objects returned
+// are complete and can be shared by schemas built in parallel.
 type Encoder struct {
-	types map[string]graphql.Type
+	mu         sync.RWMutex
+	types      map[reflect.Type]graphql.Type
+	inputTypes map[reflect.Type]graphql.Type
+	// decodedFields keeps the fields of the structs decoded from input
+	// objects, so their tags are parsed once. It only depends on the Go
+	// types, so it is not guarded by mu. decodedTypes keeps how the values
+	// of each type are decoded, and it is reset when a scalar is added.
+	decodedFields sync.Map
+	decodedTypes  sync.Map
+	// names keeps the Go type that owns each GraphQL name generated by the
+	// encoder, in order to detect collisions.
+	names  map[string]reflect.Type
+	naming NamingStrategy
+	// entries keeps the entry objects of maps by name.
+	entries     map[string]graphql.Type
+	mapStrategy MapStrategy
+	// interfaces keeps the interfaces implemented by each struct, and
+	// openObjects keeps the objects whose interfaces are not initialized
+	// yet, so interfaces can still be registered for them.
+	interfaces  map[reflect.Type][]*graphql.Interface
+	openObjects map[*graphql.Object]bool
+	// connections keeps the connection objects by the type of their nodes.
+	connections map[reflect.Type]*graphql.Object
+	// filters keeps the filter arguments by the type of their models.
+	filters map[reflect.Type]*filterSpec
+	// callbacks keeps the results of the methods implemented by the users,
+	// like `GraphqlType`, and pending is the one needed by the build in
+	// progress.
+	callbacks map[callbackKey]interface{}
+	pending   *pendingCallback
+	// undo restores the caches changed by the build in progress when it
+	// fails.
+	undo            []func()
+	nullability     Nullability
+	marshalerScalar *graphql.Scalar
+	int64Policy     Int64Policy
 }
 
-func NewEncoder() *Encoder {
-	return &Encoder{
-		types: make(map[string]graphql.Type),
+// NewEncoder returns a new `Encoder` configured with the options informed.
+func NewEncoder(options ...EncoderOption) *Encoder {
+	enc := &Encoder{
+		types:       make(map[reflect.Type]graphql.Type),
+		inputTypes:  make(map[reflect.Type]graphql.Type),
+		names:       make(map[string]reflect.Type),
+		naming:      TypeNameNaming,
+		entries:     make(map[string]graphql.Type),
+		interfaces:  make(map[reflect.Type][]*graphql.Interface),
+		openObjects: make(map[*graphql.Object]bool),
+		connections: make(map[reflect.Type]*graphql.Object),
+		filters:     make(map[reflect.Type]*filterSpec),
+		callbacks:   make(map[callbackKey]interface{}),
+	}
+	for _, option := range options {
+		option(enc)
 	}
+	return enc
 }
 
 var DefaultEncoder = NewEncoder()
@@ -71,13 +126,24 @@ func toLowerCamelCase(input string) string {
 // ```
 //
 //	type T struct {
-//	    field string `graphql:"fieldname"`
+//	    field string `graphql:"fieldname,description=The field,deprecated=use other"`
 //	}
 //
 // ```
 //
-// * fieldname: The name of the field.
+// * fieldname: The name of the field, a leading "!" makes it NonNull;
+// * description: The description of the field;
+// * deprecated: The deprecation reason of the field.
+//
+// The description and the deprecation reason can also be set by the
+// "gqldesc" and "gqldeprecated" tags.
 func (enc *Encoder) StructOf(t reflect.Type, options ...Option) (*graphql.Object, error) {
+	return build(enc, func() (*graphql.Object, error) {
+		return enc.structOf(t, options...)
+	})
+}
+
+func (enc *Encoder) structOf(t reflect.Type, options ...Option) (*graphql.Object, error) {
 	if r, ok := enc.getType(t); ok {
 		if d, ok := r.(*graphql.Object); ok {
 			return d, nil
@@ -85,14 +151,18 @@ func (enc *Encoder) StructOf(t reflect.Type, options ...Option) (*graphql.Object
 		return nil, fmt.Errorf("%s is not an graphql.Object", r)
 	}
 
-	name := t.Name()
 	if t.Kind() == reflect.Ptr {
-		name = t.Elem().Name()
+		t = t.Elem()
 	}
 
+	// The interfaces are read by `closeInterfaces`, so the interfaces
+	// registered after the object is built are listed.
 	objCfg := graphql.ObjectConfig{
-		Name:   name,
+		Name:   enc.naming(t),
 		Fields: graphql.Fields{},
+		Interfaces: graphql.InterfacesThunk(func() []*graphql.Interface {
+			return enc.interfaces[t]
+		}),
 	}
 
 	// Apply options
@@ -103,37 +173,41 @@ func (enc *Encoder) StructOf(t reflect.Type, options ...Option) (*graphql.Object
 		}
 	}
 
-	r := graphql.NewObject(objCfg)
-	enc.registerType(t, r)
-
-	if t.Kind() == reflect.Ptr {
-		t = t.Elem()
+	if err := enc.claimName(objCfg.Name, t); err != nil {
+		return nil, err
 	}
-	// Goes field by field of the object.
-
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
 
-		// if the field is not exported, ignore it
-		if field.PkgPath != "" {
-			continue
-		}
+	r := enc.newObject(objCfg)
+	enc.registerType(t, r)
+	// Goes field by field of the object.
 
-		tag, ok := field.Tag.Lookup("graphql")
-		// if there is no graphql tag look for a json tag
-		if !ok {
-			tag, ok = field.Tag.Lookup("json")
-			if ok {
-				tag = strings.Replace(tag, ",omitempty", "", -1)
-			}
-		}
-		// if is tagged with graphql or json, but is not exported, ignore it
-		if ok && tag == "-" {
-			continue
+	names := map[string]bool{}
+	for _, sf := range structFields(t, outputFieldName) {
+		field := sf.StructField
+		reader := newFieldReader(t, sf)
+		tag, _, err := outputFieldTag(field)
+		if err != nil {
+			return nil, NewErrTypeNotRecognizedWithStruct(err, t, field)
 		}
 
+		var resolve graphql.FieldResolveFn
+		strategy := enc.mapStrategy
 		objectType, ok := enc.getType(field.Type)
-		if !ok {
+		if tag.asString && isStringOptionKind(field.Type) {
+			objectType = graphql.String
+			resolve = stringOptionResolve(reader)
+		} else if cacheKey(field.Type).Kind() == reflect.Map {
+			// The map fields are not cached, because the strategy can be
+			// set by field.
+			var err error
+			strategy, err = enc.fieldMapStrategy(field)
+			if err == nil {
+				objectType, err = enc.mapOf(field.Type, strategy)
+			}
+			if err != nil {
+				return nil, NewErrTypeNotRecognizedWithStruct(err, t, field)
+			}
+		} else if !ok {
 			ot, err := enc.buildFieldType(field.Type)
 			if err != nil {
 				return nil, NewErrTypeNotRecognizedWithStruct(err, t, field)
@@ -142,33 +216,55 @@ func (enc *Encoder) StructOf(t reflect.Type, options ...Option) (*graphql.Object
 			enc.registerType(field.Type, ot)
 		}
 
-		// If the tag starts with "!" it is a NonNull type.
-		if len(tag) > 0 && tag[0] == '!' {
-			objectType = graphql.NewNonNull(objectType)
-			tag = tag[1:]
+		if tag.nonNull || (!tag.nullable && !sf.embeddedPtr && enc.inferNonNull(field.Type)) {
+			objectType = nonNullOf(objectType)
 		}
 
-		resolve := fieldResolve(field)
-
-		gqlField := &graphql.Field{
-			Type:    objectType,
-			Resolve: resolve,
+		if resolve == nil && enc.holdsMarshaler(field.Type) {
+			resolve = marshalerResolve(reader)
 		}
-		// check the field name
-		fieldName := tag
-		// if the field name was set by the tag, use it
-		if fieldName == "" {
-			// if the field name was not set by the tag
-			// build the field name by creating a camel case from the struct field name
-			fieldNameRunes := []rune(field.Name)
-			if len(tag) > 0 {
-				fieldNameRunes = []rune(tag)
-			}
-			fieldName = toLowerCamelCase(string(fieldNameRunes))
+		// The default resolver of graphql-go matches the fields by name
+		// and only reads the direct fields of the source, so the fields
+		// are read by their index.
+		if resolve == nil {
+			resolve = structFieldResolve(reader)
 		}
-		gqlField.Name = fieldName
-		AddField(r, gqlField)
+		if r := fieldResolve(field); r != nil {
+			resolve = r
+		}
+		if isValueResolver(field.Type) {
+			resolve = valueResolve(reader)
+		}
+		resolve = enc.mapEntriesResolve(field.Type, strategy, resolve)
+		resolve = enc.sqlValueResolve(field.Type, resolve)
+
+		r.AddFieldConfig(sf.name, &graphql.Field{
+			Name:              sf.name,
+			Type:              objectType,
+			Resolve:           resolve,
+			Description:       tag.description,
+			DeprecationReason: tag.deprecationReason,
+		})
+		names[sf.name] = true
 	}
+
+	methods, err := enc.methodFields(t)
+	if err != nil {
+		return nil, err
+	}
+	for name, field := range methods {
+		if names[name] {
+			return nil, fmt.Errorf("field %s of %s is defined by both a struct field and a method", name, t)
+		}
+		r.AddFieldConfig(name, field)
+	}
+
+	// graphql-go rejects objects without fields, and the error would break
+	// every schema built by the encoder.
+	if len(names) == 0 && len(methods) == 0 {
+		return nil, fmt.Errorf("%s has no fields", t)
+	}
+	freeze(r)
 	return r, nil
 }
 
@@ -196,16 +292,36 @@ func (enc *Encoder) Field(t interface{}, options ...Option) (graphql.Field, erro
 }
 
 func (enc *Encoder) ArrayOf(t reflect.Type, options ...Option) (graphql.Type, error) {
+	return build(enc, func() (graphql.Type, error) {
+		return enc.arrayOf(t, options...)
+	})
+}
+
+func (enc *Encoder) arrayOf(t reflect.Type, options ...Option) (graphql.Type, error) {
+	elemType, err := enc.elemOf(t, options...)
+	if err != nil {
+		return nil, err
+	}
+	if enc.inferNonNull(t) {
+		elemType = nonNullOf(elemType)
+	}
+	return graphql.NewList(elemType), nil
+}
+
+// elemOf returns the type of the elements of a list of t.
+func (enc *Encoder) elemOf(t reflect.Type, options ...Option) (graphql.Type, error) {
 	if t.Kind() == reflect.Ptr {
 		// If pointer, get the Type of the pointer
 		t = t.Elem()
 	}
 	var typeBuilt graphql.Type
 	if cachedType, ok := enc.getType(t); ok {
-		return graphql.NewList(cachedType), nil
+		return cachedType, nil
 	}
-	if t.Kind() == reflect.Struct {
-		bt, err := enc.StructOf(t, options...)
+	// The null wrappers and the driver.Valuer structs are represented by
+	// the types of their values, and the connections by their objects.
+	if t.Kind() == reflect.Struct && !enc.isNullWrapper(t) && !enc.isDriverValuer(t) && !isConnection(t) {
+		bt, err := enc.structOf(t, options...)
 		if err != nil {
 			return nil, err
 		}
@@ -218,10 +334,16 @@ func (enc *Encoder) ArrayOf(t reflect.Type, options ...Option) (graphql.Type, er
 		typeBuilt = ttt
 	}
 	enc.registerType(t, typeBuilt)
-	return graphql.NewList(typeBuilt), nil
+	return typeBuilt, nil
 }
 
 func (enc *Encoder) InputObjectFieldMap(t reflect.Type) (graphql.InputObjectConfigFieldMap, error) {
+	return build(enc, func() (graphql.InputObjectConfigFieldMap, error) {
+		return enc.inputObjectFieldMap(t)
+	})
+}
+
+func (enc *Encoder) inputObjectFieldMap(t reflect.Type) (graphql.InputObjectConfigFieldMap, error) {
 	r := graphql.InputObjectConfigFieldMap{}
 
 	if t.Kind() == reflect.Ptr {
@@ -232,48 +354,20 @@ func (enc *Encoder) InputObjectFieldMap(t reflect.Type) (graphql.InputObjectConf
 		return r, fmt.Errorf("cannot build args from a non struct")
 	}
 
-	// Goes field by field of the object.
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		tag, ok := field.Tag.Lookup("graphql")
-		// if is tagged with graphql, but is not exported, ignore it
-		if ok && tag == "-" {
-			continue
-		}
-
-		objectType, ok := enc.getType(field.Type)
-		if !ok {
-			ot, err := enc.buildFieldType(field.Type)
-			if err != nil {
-				return nil, NewErrTypeNotRecognizedWithStruct(err, t, field)
-			}
-			objectType = ot
-			enc.registerType(field.Type, ot)
-		}
-
-		// If the tag starts with "!" it is a NonNull type.
-		if len(tag) > 0 && tag[0] == '!' {
-			objectType = graphql.NewNonNull(objectType)
-			tag = tag[1:]
-		}
-
-		inputField := &graphql.InputObjectFieldConfig{
-			Type: objectType,
-		}
-
-		fieldName := []rune(field.Name)
-		if len(tag) > 0 {
-			fieldName = []rune(tag)
-		}
-		fieldNameS := toLowerCamelCase(string(fieldName))
-
-		r[fieldNameS] = inputField
+	err := enc.inputFields(t, r)
+	if err != nil {
+		return nil, err
 	}
-
 	return r, nil
 }
 
 func (enc *Encoder) ArgsOf(t reflect.Type) (graphql.FieldConfigArgument, error) {
+	return build(enc, func() (graphql.FieldConfigArgument, error) {
+		return enc.argsOf(t)
+	})
+}
+
+func (enc *Encoder) argsOf(t reflect.Type) (graphql.FieldConfigArgument, error) {
 	r := graphql.FieldConfigArgument{}
 
 	if t.Kind() == reflect.Ptr {
@@ -284,67 +378,132 @@ func (enc *Encoder) ArgsOf(t reflect.Type) (graphql.FieldConfigArgument, error)
 		return r, fmt.Errorf("cannot build args from a non struct")
 	}
 
-	// Goes field by field of the object.
-	for i := 0; i < t.NumField(); i++ {
-		field := t.Field(i)
-		tag, ok := field.Tag.Lookup("graphql")
-		// if is tagged with graphql, but is not exported, ignore it
-		if ok && tag == "-" {
-			continue
-		}
-
-		objectType, ok := enc.getType(field.Type)
-		if !ok {
-			ot, err := enc.buildFieldType(field.Type)
-			if err != nil {
-				return nil, NewErrTypeNotRecognizedWithStruct(err, t, field)
-			}
-			objectType = ot
-			enc.registerType(field.Type, ot)
-		}
+	fields := graphql.InputObjectConfigFieldMap{}
+	err := enc.inputFields(t, fields)
+	if err != nil {
+		return nil, err
+	}
 
-		// If the tag starts with "!" it is a NonNull type.
-		if len(tag) > 0 && tag[0] == '!' {
-			objectType = graphql.NewNonNull(objectType)
-			tag = tag[1:]
+	for name, field := range fields {
+		r[name] = &graphql.ArgumentConfig{
+			Type:         field.Type,
+			DefaultValue: field.DefaultValue,
+			Description:  field.Description,
 		}
+	}
 
-		graphQLArgument := &graphql.ArgumentConfig{
-			Type: objectType,
-		}
+	return r, nil
+}
 
-		fieldName := []rune(field.Name)
-		if len(tag) > 0 {
-			fieldName = []rune(tag)
-		}
-		fieldNameS := toLowerCamelCase(string(fieldName))
+// TypeOf returns the GraphQL type of any Go type supported by the encoder,
+// i.e. structs, slices and scalars.
+func (enc *Encoder) TypeOf(t reflect.Type) (graphql.Type, error) {
+	return build(enc, func() (graphql.Type, error) {
+		return enc.typeOf(t)
+	})
+}
 
-		r[fieldNameS] = graphQLArgument
+func (enc *Encoder) typeOf(t reflect.Type) (graphql.Type, error) {
+	r, err := enc.buildFieldType(t)
+	if err != nil {
+		return nil, err
 	}
-
+	enc.registerType(t, r)
 	return r, nil
 }
 
 func (enc *Encoder) getType(t reflect.Type) (graphql.Type, bool) {
-	name := t.Name()
-	if t.Kind() == reflect.Ptr {
-		name = t.Elem().Name()
+	gt, ok := enc.types[cacheKey(t)]
+	return gt, ok
+}
+
+func (enc *Encoder) registerType(t reflect.Type, r graphql.Type) {
+	setCache(enc, enc.types, cacheKey(t), r)
+}
+
+// newObject returns a new object, which is open until its interfaces are
+// initialized by `closeInterfaces`.
+func (enc *Encoder) newObject(cfg graphql.ObjectConfig) *graphql.Object {
+	r := graphql.NewObject(cfg)
+	setCache(enc, enc.openObjects, r, true)
+	return r
+}
+
+// freeze initializes the fields of the types informed, or the types of the
+// unions. graphql-go initializes them the first time they are read, so they
+// are initialized when they are built, holding the lock of the encoder, and
+// they are not modified when they are read by concurrent schemas. The
+// interfaces of the objects are initialized by `closeInterfaces`.
+func freeze(types ...graphql.Type) {
+	for _, t := range types {
+		switch t := t.(type) {
+		case *graphql.Object:
+			t.Fields()
+		case *graphql.Interface:
+			t.Fields()
+		case *graphql.InputObject:
+			t.Fields()
+		case *graphql.Union:
+			t.Types()
+		}
 	}
-	if len(name) > 0 {
-		gt, ok := enc.types[name]
-		return gt, ok
+}
+
+// Types returns the named types built by the encoder, both output and input
+// types, sorted by name.
+//
+// The types are meant to be added to a schema, so the interfaces of the
+// objects are initialized by `closeInterfaces`: no interface can be
+// registered for the structs built so far once Types is called, i.e. by
+// `SchemaBuilder.Build` or `PrintSDL`.
+func (enc *Encoder) Types() []graphql.Type {
+	enc.mu.Lock()
+	defer enc.mu.Unlock()
+
+	for obj := range enc.openObjects {
+		enc.closeInterfaces(obj)
 	}
-	return nil, false
+	return enc.namedTypes()
 }
 
-func (enc *Encoder) registerType(t reflect.Type, r graphql.Type) {
-	name := t.Name()
-	if t.Kind() == reflect.Ptr {
-		name = t.Elem().Name()
+func (enc *Encoder) namedTypes() []graphql.Type {
+	seen := map[string]bool{}
+	r := []graphql.Type{}
+	add := func(t graphql.Type) {
+		switch t.(type) {
+		case *graphql.List, *graphql.NonNull:
+			return
+		}
+		if seen[t.Name()] {
+			return
+		}
+		seen[t.Name()] = true
+		r = append(r, t)
 	}
-	if len(name) > 0 {
-		enc.types[name] = r
+	for _, cache := range []map[reflect.Type]graphql.Type{enc.types, enc.inputTypes} {
+		for _, t := range cache {
+			add(t)
+		}
+	}
+	// The connections, the filters and the entries, i.e. the patch inputs,
+	// are not cached with the types, they are built for their nodes and
+	// models.
+	for _, t := range enc.connections {
+		add(t)
+	}
+	for _, spec := range enc.filters {
+		add(spec.filter)
+		if spec.orderBy != nil {
+			add(spec.orderBy)
+		}
+	}
+	for _, t := range enc.entries {
+		add(t)
 	}
+	sort.Slice(r, func(i, j int) bool {
+		return r[i].Name() < r[j].Name()
+	})
+	return r
 }
 
 func Struct(obj interface{}) *graphql.Object {
@@ -0,0 +1,160 @@
+package gql_auto
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"github.com/graphql-go/graphql"
+)
+
+// GraphqlEnum is the interface implemented by types that are represented by
+// a GraphQL enum, usually a string or int type with a set of constants.
+//
+// ```
+//
+//	type Status string
+//
+//	const (
+//	    StatusActive   Status = "ACTIVE"
+//	    StatusInactive Status = "INACTIVE"
+//	)
+//
+//	func (Status) GraphqlEnumValues() []gql_auto.EnumValue {
+//	    return []gql_auto.EnumValue{
+//	        {Value: StatusActive, Description: "The user can log in"},
+//	        {Value: StatusInactive},
+//	    }
+//	}
+//
+// ```
+type GraphqlEnum interface {
+	// GraphqlEnumValues returns the values of the enum.
+	GraphqlEnumValues() []EnumValue
+}
+
+// EnumValue describes a value of a GraphQL enum.
+type EnumValue struct {
+	// Name is the name of the value in the schema. When it is empty, the
+	// name is obtained from the value itself.
+	Name string
+	// Value is the Go constant represented by the enum value.
+	Value             interface{}
+	Description       string
+	DeprecationReason string
+}
+
+var graphqlEnumType = reflect.TypeOf(new(GraphqlEnum)).Elem()
+
+// RegisterEnum registers the Go type t as a GraphQL enum with the values
+// informed. When no value is informed, the values are obtained from the
+// `GraphqlEnum` interface.
+//
+// The fields of type t, in both objects and arguments, become fields of the
+// enum, and the arguments are decoded back into the Go constants.
+func (enc *Encoder) RegisterEnum(t reflect.Type, values ...EnumValue) (*graphql.Enum, error) {
+	return build(enc, func() (*graphql.Enum, error) {
+		return enc.enumOf(t, values)
+	})
+}
+
+func (enc *Encoder) enumOf(t reflect.Type, values []EnumValue) (*graphql.Enum, error) {
+	t = cacheKey(t)
+	if r, ok := enc.getType(t); ok {
+		if d, ok := r.(*graphql.Enum); ok {
+			return d, nil
+		}
+		return nil, fmt.Errorf("%s was already built as %s", t, r)
+	}
+
+	if len(values) == 0 {
+		enum, ok := reflect.New(t).Interface().(GraphqlEnum)
+		if !ok {
+			return nil, fmt.Errorf("%s has no enum values", t)
+		}
+		var err error
+		values, err = callback(enc, t, "GraphqlEnumValues", enum.GraphqlEnumValues)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	name := enc.naming(t)
+	cfg := graphql.EnumConfig{
+		Name:   name,
+		Values: graphql.EnumValueConfigMap{},
+	}
+	for _, value := range values {
+		v := reflect.ValueOf(value.Value)
+		if !v.IsValid() || !v.Type().ConvertibleTo(t) {
+			return nil, fmt.Errorf("enum value %v is not a %s", value.Value, t)
+		}
+		v = v.Convert(t)
+
+		valueName := value.Name
+		if valueName == "" {
+			valueName = enumValueName(v)
+		}
+		cfg.Values[valueName] = &graphql.EnumValueConfig{
+			Value:             v.Interface(),
+			Description:       value.Description,
+			DeprecationReason: value.DeprecationReason,
+		}
+	}
+
+	err := enc.claimName(name, t)
+	if err != nil {
+		return nil, err
+	}
+	r := graphql.NewEnum(cfg)
+	if r.Error() != nil {
+		return nil, r.Error()
+	}
+	enc.registerType(t, r)
+	return r, nil
+}
+
+// isGraphqlEnum checks if the type, or a pointer to it, implements the
+// `GraphqlEnum` interface.
+func isGraphqlEnum(t reflect.Type) bool {
+	return t.Implements(graphqlEnumType) || reflect.PtrTo(t).Implements(graphqlEnumType)
+}
+
+// enumValueName returns the name of an enum value built from the value
+// itself, i.e. "active" -> ACTIVE.
+func enumValueName(v reflect.Value) string {
+	var s string
+	if stringer, ok := v.Interface().(fmt.Stringer); ok {
+		s = stringer.String()
+	} else {
+		s = fmt.Sprint(v.Interface())
+	}
+	return toUpperSnakeCase(s)
+}
+
+// toUpperSnakeCase converts the input to a valid upper snake case name, i.e.
+// firstName -> FIRST_NAME.
+func toUpperSnakeCase(input string) string {
+	b := strings.Builder{}
+	runes := []rune(input)
+	for i, r := range runes {
+		switch {
+		case unicode.IsUpper(r):
+			if i > 0 && (unicode.IsLower(runes[i-1]) || unicode.IsDigit(runes[i-1]) ||
+				(i+1 < len(runes) && unicode.IsLower(runes[i+1]) && unicode.IsUpper(runes[i-1]))) {
+				b.WriteRune('_')
+			}
+			b.WriteRune(r)
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(unicode.ToUpper(r))
+		default:
+			b.WriteRune('_')
+		}
+	}
+	s := b.String()
+	if s != "" && unicode.IsDigit([]rune(s)[0]) {
+		s = "_" + s
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type EnumStatus string
+
+const (
+	EnumStatusActive   EnumStatus = "ACTIVE"
+	EnumStatusInactive EnumStatus = "INACTIVE"
+)
+
+func (EnumStatus) GraphqlEnumValues() []gql_auto.EnumValue {
+	return []gql_auto.EnumValue{
+		{Value: EnumStatusActive, Description: "The user can log in"},
+		{Value: EnumStatusInactive, DeprecationReason: "use DELETED"},
+	}
+}
+
+type EnumLevel int
+
+const (
+	EnumLevelLow EnumLevel = iota
+	EnumLevelHigh
+)
+
+type EnumUser struct {
+	Name      string      `graphql:"!name"`
+	Status    EnumStatus  `graphql:"!status"`
+	Level     *EnumLevel  `graphql:"level"`
+	Previous  []EnumLevel `graphql:"previous"`
+	Unchanged string      `graphql:"unchanged"`
+}
+
+type EnumUserArgs struct {
+	Status EnumStatus  `graphql:"!status"`
+	Levels []EnumLevel `graphql:"levels"`
+}
+
+func TestEncoder_Enum(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	level, err := enc.RegisterEnum(reflect.TypeOf(EnumLevel(0)),
+		gql_auto.EnumValue{Name: "LOW", Value: EnumLevelLow},
+		gql_auto.EnumValue{Name: "HIGH", Value: EnumLevelHigh},
+	)
+	ass.NoError(err)
+	ass.Equal("EnumLevel", level.Name())
+
+	obj, err := enc.Struct(EnumUser{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal("EnumStatus!", fields["status"].Type.String())
+	ass.Equal(level, fields["level"].Type)
+	ass.Equal("[EnumLevel]", fields["previous"].Type.String())
+	ass.Equal(graphql.String, fields["unchanged"].Type)
+
+	status := graphql.GetNamed(fields["status"].Type).(*graphql.Enum)
+	ass.Len(status.Values(), 2)
+
+	var decoded EnumUserArgs
+	schema, err := enc.SchemaBuilder().
+		Query("user", EnumUserArgs{}, EnumUser{}, func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, &decoded)
+			high := EnumLevelHigh
+			return EnumUser{Name: "Duke", Status: decoded.Status, Level: &high, Previous: decoded.Levels}, err
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ user(status: INACTIVE, levels: [LOW, HIGH]) { name status level previous } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(EnumUserArgs{Status: EnumStatusInactive, Levels: []EnumLevel{EnumLevelLow, EnumLevelHigh}}, decoded)
+	ass.Equal(map[string]interface{}{
+		"user": map[string]interface{}{
+			"name":     "Duke",
+			"status":   "INACTIVE",
+			"level":    "HIGH",
+			"previous": []interface{}{"LOW", "HIGH"},
+		},
+	}, res.Data)
+
+	ass.Contains(enc.PrintSDL(), `enum EnumStatus {
+  """The user can log in"""
+  ACTIVE
+  INACTIVE @deprecated(reason: "use DELETED")
+}`)
+}
+
+func TestEncoder_RegisterEnumDerivedNames(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type Color string
+	enum, err := gql_auto.NewEncoder().RegisterEnum(reflect.TypeOf(Color("")),
+		gql_auto.EnumValue{Value: "darkRed"},
+		gql_auto.EnumValue{Value: "light blue"},
+	)
+	ass.NoError(err)
+	names := []string{}
+	for _, value := range enum.Values() {
+		names = append(names, value.Name)
+	}
+	ass.ElementsMatch([]string{"DARK_RED", "LIGHT_BLUE"}, names)
+}
+
+func TestEncoder_RegisterEnumErrors(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type Color string
+	enc := gql_auto.NewEncoder()
+	_, err := enc.RegisterEnum(reflect.TypeOf(Color("")))
+	ass.ErrorContains(err, "has no enum values")
+
+	_, err = enc.RegisterEnum(reflect.TypeOf(Color("")), gql_auto.EnumValue{Value: 1.5})
+	ass.ErrorContains(err, "is not a")
+
+	type Example struct {
+		Color Color `graphql:"color"`
+	}
+	_, err = enc.Struct(Example{})
+	ass.NoError(err)
+	_, err = enc.RegisterEnum(reflect.TypeOf(Color("")), gql_auto.EnumValue{Value: "RED"})
+	ass.ErrorContains(err, "already built")
+}
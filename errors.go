@@ -36,3 +36,61 @@ func NewErrTypeNotRecognizedWithStruct(reason error, structType reflect.Type, st
 		fieldStruct: structField,
 	}
 }
+
+// DecodeError is returned when a value cannot be decoded into a Go type.
+type DecodeError struct {
+	path   string
+	reason error
+}
+
+func (err *DecodeError) Error() string {
+	if err.path == "" {
+		return err.reason.Error()
+	}
+	return fmt.Sprintf("%s: %s", err.path, err.reason.Error())
+}
+
+// Path returns the path of the value that could not be decoded, i.e.
+// `person.addresses[0].zip`.
+func (err *DecodeError) Path() string {
+	return err.path
+}
+
+func (err *DecodeError) Unwrap() error {
+	return err.reason
+}
+
+func NewErrDecode(path string, reason error) error {
+	return &DecodeError{
+		path:   path,
+		reason: reason,
+	}
+}
+
+// NameCollisionError is returned when two different Go types would be mapped
+// to the same GraphQL name.
+type NameCollisionError struct {
+	name  string
+	first reflect.Type
+	other reflect.Type
+}
+
+func (err *NameCollisionError) Error() string {
+	return fmt.Sprintf("graphql name '%s' of %s already used by %s", err.name, qualifiedName(err.other), qualifiedName(err.first))
+}
+
+func NewErrNameCollision(name string, first reflect.Type, other reflect.Type) error {
+	return &NameCollisionError{
+		name:  name,
+		first: first,
+		other: other,
+	}
+}
+
+// qualifiedName returns the name of the type including its package path.
+func qualifiedName(t reflect.Type) string {
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+	return t.PkgPath() + "." + t.Name()
+}
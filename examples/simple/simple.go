@@ -12,33 +12,37 @@ type Person struct {
 	Friends []Person `graphql:"friends"`
 }
 
+type HeroArgs struct {
+	Name string `graphql:"name"`
+}
+
 // main will initialize the schema and start a HTTP server on port 8080.
 func main() {
 	// It creates the schema
-	schema, err := graphql.NewSchema(graphql.SchemaConfig{
-		Query: graphql.NewObject(graphql.ObjectConfig{
-			Name: "Query",
-			Fields: graphql.Fields{
-				"hero": &graphql.Field{
-					Type: gql_auto.Struct(&Person{}),
-					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-						return &Person{
-							Name: "Snake Eyes",
-							Age:  1,
-							Friends: []Person{
-								{
-									Name: "Scarlett",
-								},
-								{
-									Name: "Duke",
-								},
-							},
-						}, nil
+	schema, err := gql_auto.NewSchemaBuilder().
+		Query("hero", HeroArgs{}, Person{}, func(p graphql.ResolveParams) (interface{}, error) {
+			var args HeroArgs
+			if err := gql_auto.DecodeArgs(p, &args); err != nil {
+				return nil, err
+			}
+			name := args.Name
+			if name == "" {
+				name = "Snake Eyes"
+			}
+			return &Person{
+				Name: name,
+				Age:  1,
+				Friends: []Person{
+					{
+						Name: "Scarlett",
+					},
+					{
+						Name: "Duke",
 					},
 				},
-			},
-		}), // Create the type based on the Query{} struct
-	})
+			}, nil
+		}).
+		Build()
 
 	if err != nil {
 		panic(err)
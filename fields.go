@@ -0,0 +1,205 @@
+package gql_auto
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// structField is a field of a struct, including the fields promoted from
+// embedded structs.
+type structField struct {
+	reflect.StructField
+	// index is the path of the field from the root struct, it can be used
+	// with `reflect.Value.FieldByIndex`.
+	index []int
+	// name is the GraphQL name of the field.
+	name string
+	// tagged is true when the name of the field was set by a tag.
+	tagged bool
+	// embeddedPtr is true when the field is promoted from a pointer to an
+	// embedded struct, so it cannot be reached when the pointer is nil.
+	embeddedPtr bool
+}
+
+// fieldNameFn returns the GraphQL name of a struct field. ok is false when
+// the field must be ignored.
+type fieldNameFn func(field reflect.StructField) (name string, ok bool)
+
+// structFields returns the fields of the struct t, promoting the fields of
+// embedded structs following the rules of Go and `encoding/json`:
+//
+// * The fields of embedded structs, or pointers to structs, are promoted
+// unless the embedded field has an explicit name in its tag;
+// * A field hides the fields with the same name that are deeper in the
+// embedding hierarchy;
+// * When there are many fields with the same name at the same depth, the one
+// with a tag wins. If that is not enough, all of them are ignored.
+//
+// The fields are returned in the order they are declared.
+func structFields(t reflect.Type, nameOf fieldNameFn) []structField {
+	type level struct {
+		typ         reflect.Type
+		index       []int
+		embeddedPtr bool
+	}
+
+	var fields []structField
+	current := []level{}
+	next := []level{{typ: cacheKey(t)}}
+	visited := map[reflect.Type]bool{}
+
+	for len(next) > 0 {
+		current, next = next, current[:0]
+
+		// The types are marked as visited once their level is done, so a
+		// type embedded twice at the same depth makes its fields ambiguous,
+		// while recursive embeds end.
+		for _, l := range current {
+			if visited[l.typ] {
+				continue
+			}
+
+			for i := 0; i < l.typ.NumField(); i++ {
+				field := l.typ.Field(i)
+				index := make([]int, len(l.index)+1)
+				copy(index, l.index)
+				index[len(l.index)] = i
+
+				ft := cacheKey(field.Type)
+				if field.Anonymous && ft.Kind() == reflect.Struct && explicitTagName(field) == "" {
+					if ignoredByTag(field) {
+						continue
+					}
+					next = append(next, level{
+						typ:         ft,
+						index:       index,
+						embeddedPtr: l.embeddedPtr || field.Type.Kind() == reflect.Ptr,
+					})
+					continue
+				}
+				// if the field is not exported, ignore it
+				if field.PkgPath != "" {
+					continue
+				}
+
+				name, ok := nameOf(field)
+				if !ok {
+					continue
+				}
+				fields = append(fields, structField{
+					StructField: field,
+					index:       index,
+					name:        name,
+					tagged:      explicitTagName(field) != "",
+					embeddedPtr: l.embeddedPtr,
+				})
+			}
+		}
+		for _, l := range current {
+			visited[l.typ] = true
+		}
+	}
+
+	// Sorts the fields by name, depth and tag, so the dominant field is the
+	// first of each name.
+	sort.SliceStable(fields, func(i, j int) bool {
+		if fields[i].name != fields[j].name {
+			return fields[i].name < fields[j].name
+		}
+		if len(fields[i].index) != len(fields[j].index) {
+			return len(fields[i].index) < len(fields[j].index)
+		}
+		return fields[i].tagged && !fields[j].tagged
+	})
+
+	r := fields[:0]
+	for i := 0; i < len(fields); {
+		j := i + 1
+		for j < len(fields) && fields[j].name == fields[i].name {
+			j++
+		}
+		if dominant, ok := dominantField(fields[i:j]); ok {
+			r = append(r, dominant)
+		}
+		i = j
+	}
+
+	// Restores the order of declaration.
+	sort.Slice(r, func(i, j int) bool {
+		return indexLess(r[i].index, r[j].index)
+	})
+	return r
+}
+
+// dominantField returns the field that wins among fields with the same name,
+// sorted by depth and tag.
+func dominantField(fields []structField) (structField, bool) {
+	if len(fields) > 1 && len(fields[0].index) == len(fields[1].index) && fields[0].tagged == fields[1].tagged {
+		return structField{}, false
+	}
+	return fields[0], true
+}
+
+func indexLess(a []int, b []int) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return a[i] < b[i]
+		}
+	}
+	return len(a) < len(b)
+}
+
+// explicitTagName returns the name set by the "graphql" or "json" tags of the
+// field, without the options.
+func explicitTagName(field reflect.StructField) string {
+	tag, ok := field.Tag.Lookup("graphql")
+	if !ok {
+		tag = field.Tag.Get("json")
+	}
+	tag = strings.TrimPrefix(tag, "!")
+	if i := strings.Index(tag, ","); i >= 0 {
+		tag = tag[:i]
+	}
+	if tag == "-" {
+		return ""
+	}
+	return tag
+}
+
+// ignoredByTag checks if the "graphql" tag, or the "json" tag when there is
+// no "graphql" tag, is "-".
+func ignoredByTag(field reflect.StructField) bool {
+	tag, ok := field.Tag.Lookup("graphql")
+	if !ok {
+		tag = field.Tag.Get("json")
+	}
+	return tag == "-"
+}
+
+// addressableCopy returns a pointer to a copy of v. The methods with both
+// value and pointer receivers of the type of v can be called on it.
+func addressableCopy(v reflect.Value) reflect.Value {
+	r := reflect.New(v.Type())
+	r.Elem().Set(v)
+	return r
+}
+
+// fieldByIndexAlloc returns the field of v with the index informed,
+// allocating the nil pointers to embedded structs found in the way.
+func fieldByIndexAlloc(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return reflect.Value{}, fmt.Errorf("cannot set embedded pointer to unexported struct %s", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
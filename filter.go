@@ -0,0 +1,483 @@
+package gql_auto
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// FilterOperator is an operator of the conditions of a `Filter`.
+type FilterOperator string
+
+const (
+	// FilterEq matches the values equal to the value of the condition.
+	FilterEq FilterOperator = "eq"
+	// FilterNeq matches the values not equal to the value of the condition.
+	FilterNeq FilterOperator = "neq"
+	// FilterIn matches the values in the slice of the condition.
+	FilterIn FilterOperator = "in"
+	// FilterLt matches the values less than the value of the condition.
+	FilterLt FilterOperator = "lt"
+	// FilterGt matches the values greater than the value of the condition.
+	FilterGt FilterOperator = "gt"
+	// FilterContains matches the strings that contain the string of the
+	// condition.
+	FilterContains FilterOperator = "contains"
+	// FilterIsNull matches the null values when the value of the condition
+	// is true, and the other values when it is false.
+	FilterIsNull FilterOperator = "isNull"
+)
+
+// The operators of the fields by their GraphQL types.
+var (
+	textOperators     = []FilterOperator{FilterEq, FilterNeq, FilterIn, FilterContains, FilterIsNull}
+	orderedOperators  = []FilterOperator{FilterEq, FilterNeq, FilterIn, FilterLt, FilterGt, FilterIsNull}
+	booleanOperators  = []FilterOperator{FilterEq, FilterNeq, FilterIsNull}
+	equalityOperators = []FilterOperator{FilterEq, FilterNeq, FilterIn, FilterIsNull}
+)
+
+const (
+	filterTypeSuffix  = "Filter"
+	orderByTypeSuffix = "OrderBy"
+)
+
+// Filter is a node of the filter AST decoded by `DecodeFilterArgs`. A value
+// matches the filter when it matches every condition and every filter of
+// And, at least one filter of Or, when there is any, and it does not match
+// Not.
+type Filter struct {
+	Conditions []Condition
+	And        []Filter
+	Or         []Filter
+	Not        *Filter
+}
+
+// Condition compares a field of the model with a value.
+type Condition struct {
+	// Field is the GraphQL name of the field.
+	Field string
+	// Index is the index of the Go field, as used by
+	// `reflect.Value.FieldByIndex`.
+	Index    []int
+	Operator FilterOperator
+	// Value is a value of the Go type of the field, a slice of them for
+	// `FilterIn`, a string for `FilterContains` and a bool for
+	// `FilterIsNull`. The pointers and the null wrappers, like
+	// sql.NullString, are compared by the type of their values.
+	Value interface{}
+}
+
+// Order sorts the values by a field of the model.
+type Order struct {
+	// Field is the GraphQL name of the field.
+	Field string
+	// Index is the index of the Go field, as used by
+	// `reflect.Value.FieldByIndex`.
+	Index []int
+	Desc  bool
+}
+
+// FilterArgs are the arguments built by `FilterArgsOf`, decoded by
+// `DecodeFilterArgs`. The fields are nil when the arguments are not
+// informed.
+type FilterArgs struct {
+	Filter  *Filter
+	OrderBy []Order
+	Limit   *int
+	Offset  *int
+}
+
+// filterSpec keeps the types of the filter arguments of a model, and the
+// fields they refer to.
+type filterSpec struct {
+	filter  *graphql.InputObject
+	orderBy *graphql.Enum
+	fields  []filterField
+	orders  map[string]Order
+}
+
+// filterField is a field of the model that can be filtered.
+type filterField struct {
+	name      string
+	index     []int
+	valueType reflect.Type
+}
+
+// FilterArgsOf returns the arguments that filter, sort and paginate the
+// values of the struct t:
+//
+// * filter: The "<Model>Filter" input object, with the operators of each
+// field and the "and", "or" and "not" compositions;
+// * orderBy: The list of "<Model>OrderBy" enum values, i.e. NAME_ASC or
+// NAME_DESC;
+// * limit and offset: The page of the values.
+//
+// The operators depend on the type of the field:
+//
+// * String and ID: eq, neq, in, contains and isNull;
+// * Int, Float, Long, BigInt, DateTime, Date and Duration: eq, neq, in, lt,
+// gt and isNull;
+// * Boolean: eq, neq and isNull;
+// * Enums and other scalars: eq, neq, in and isNull.
+//
+// The fields of other types, like objects and lists, cannot be filtered.
+// The enums and the scalars, except the custom ones, can be sorted.
+func (enc *Encoder) FilterArgsOf(t reflect.Type) (graphql.FieldConfigArgument, error) {
+	spec, err := build(enc, func() (*filterSpec, error) {
+		return enc.filterSpecOf(t)
+	})
+	if err != nil {
+		return nil, err
+	}
+	r := graphql.FieldConfigArgument{
+		"filter": &graphql.ArgumentConfig{
+			Type: spec.filter,
+		},
+		"limit": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "The maximum number of values.",
+		},
+		"offset": &graphql.ArgumentConfig{
+			Type:        graphql.Int,
+			Description: "The number of values skipped.",
+		},
+	}
+	if spec.orderBy != nil {
+		r["orderBy"] = &graphql.ArgumentConfig{
+			Type:        graphql.NewList(graphql.NewNonNull(spec.orderBy)),
+			Description: "The fields that sort the values, in order.",
+		}
+	}
+	return r, nil
+}
+
+func (enc *Encoder) filterSpecOf(t reflect.Type) (*filterSpec, error) {
+	t = cacheKey(t)
+	if r, ok := enc.filters[t]; ok {
+		return r, nil
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot build the filter of %s, it is not a struct", t)
+	}
+
+	name := enc.naming(t)
+	filterName := name + filterTypeSuffix
+	err := enc.claimName(filterName, t)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := &filterSpec{orders: map[string]Order{}}
+	fields := graphql.InputObjectConfigFieldMap{}
+	spec.filter = graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        filterName,
+		Description: fmt.Sprintf("The filter of the %s values.", name),
+		Fields:      fields,
+	})
+	orderValues := graphql.EnumValueConfigMap{}
+
+	for _, sf := range structFields(t, outputFieldName) {
+		valueType := filterValueType(sf.Type)
+		if !enc.isFilterable(valueType) {
+			continue
+		}
+		inputType, err := enc.buildInputFieldType(valueType)
+		if err != nil {
+			return nil, NewErrTypeNotRecognizedWithStruct(err, t, sf.StructField)
+		}
+		operators, sortable := filterOperators(inputType)
+		if operators == nil {
+			continue
+		}
+		operatorsType, err := enc.filterOperatorsOf(inputType, operators, valueType)
+		if err != nil {
+			return nil, err
+		}
+		fields[sf.name] = &graphql.InputObjectFieldConfig{Type: operatorsType}
+		spec.fields = append(spec.fields, filterField{name: sf.name, index: sf.index, valueType: valueType})
+
+		if sortable {
+			for _, desc := range []bool{false, true} {
+				valueName := toUpperSnakeCase(sf.name) + "_ASC"
+				if desc {
+					valueName = toUpperSnakeCase(sf.name) + "_DESC"
+				}
+				orderValues[valueName] = &graphql.EnumValueConfig{Value: valueName}
+				spec.orders[valueName] = Order{Field: sf.name, Index: sf.index, Desc: desc}
+			}
+		}
+	}
+
+	fields["and"] = &graphql.InputObjectFieldConfig{
+		Type:        graphql.NewList(graphql.NewNonNull(spec.filter)),
+		Description: "Matches the values that match all the filters.",
+	}
+	fields["or"] = &graphql.InputObjectFieldConfig{
+		Type:        graphql.NewList(graphql.NewNonNull(spec.filter)),
+		Description: "Matches the values that match any of the filters.",
+	}
+	fields["not"] = &graphql.InputObjectFieldConfig{
+		Type:        spec.filter,
+		Description: "Matches the values that do not match the filter.",
+	}
+	freeze(spec.filter)
+
+	if len(orderValues) > 0 {
+		orderByName := name + orderByTypeSuffix
+		err := enc.claimName(orderByName, t)
+		if err != nil {
+			return nil, err
+		}
+		spec.orderBy = graphql.NewEnum(graphql.EnumConfig{
+			Name:        orderByName,
+			Description: fmt.Sprintf("The fields that sort the %s values.", name),
+			Values:      orderValues,
+		})
+	}
+
+	setCache(enc, enc.filters, t, spec)
+	return spec, nil
+}
+
+// filterValueType returns the type of the values compared by the conditions
+// of a field of type t. The pointers and the null wrappers are compared by
+// the type of their values.
+func filterValueType(t reflect.Type) reflect.Type {
+	for {
+		if t.Kind() == reflect.Ptr {
+			t = t.Elem()
+			continue
+		}
+		if field, ok := nullWrapperValue(t); ok {
+			t = field.Type
+			continue
+		}
+		return t
+	}
+}
+
+// isFilterable checks if the values of the type t are represented by scalars
+// or enums, without building the types of the other values.
+func (enc *Encoder) isFilterable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct, reflect.Array:
+		if t == timeType || t == uuidType {
+			return true
+		}
+		if r, ok := enc.getType(t); ok {
+			switch r.(type) {
+			case *graphql.Scalar, *graphql.Enum:
+				return true
+			}
+			return false
+		}
+		return isGraphqlEnum(t) || t.Implements(graphqlTypedType) || reflect.PtrTo(t).Implements(graphqlTypedType)
+	case reflect.Slice, reflect.Map, reflect.Interface, reflect.Func, reflect.Chan,
+		reflect.UnsafePointer, reflect.Complex64, reflect.Complex128:
+		return false
+	}
+	return true
+}
+
+// filterOperators returns the operators of the fields of the input type t,
+// and whether they can be sorted. The operators are nil when the fields
+// cannot be filtered.
+func filterOperators(t graphql.Input) ([]FilterOperator, bool) {
+	switch t := t.(type) {
+	case *graphql.Enum:
+		return equalityOperators, true
+	case *graphql.Scalar:
+		switch t.Name() {
+		case "String", "ID":
+			return textOperators, true
+		case "Int", "Float", "Long", "BigInt", "DateTime", "Date", "Duration":
+			return orderedOperators, true
+		case "Boolean":
+			return booleanOperators, true
+		case "JSON":
+			return nil, false
+		}
+		return equalityOperators, false
+	}
+	return nil, false
+}
+
+// filterOperatorsOf returns the input object with the operators of the
+// fields of the type t, i.e. StringFilter. They are shared by the filters of
+// every model, and owned by the Go type of the first field.
+func (enc *Encoder) filterOperatorsOf(t graphql.Input, operators []FilterOperator, valueType reflect.Type) (*graphql.InputObject, error) {
+	name := t.Name() + filterTypeSuffix
+	if r, ok := enc.entries[name]; ok {
+		return r.(*graphql.InputObject), nil
+	}
+	err := enc.claimName(name, valueType)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := graphql.InputObjectConfigFieldMap{}
+	for _, operator := range operators {
+		var fieldType graphql.Input = t
+		switch operator {
+		case FilterIn:
+			fieldType = graphql.NewList(graphql.NewNonNull(t))
+		case FilterContains:
+			fieldType = graphql.String
+		case FilterIsNull:
+			fieldType = graphql.Boolean
+		}
+		fields[string(operator)] = &graphql.InputObjectFieldConfig{Type: fieldType}
+	}
+	r := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        name,
+		Description: fmt.Sprintf("The operators of the %s fields.", t.Name()),
+		Fields:      fields,
+	})
+	freeze(r)
+	setCache(enc, enc.entries, name, graphql.Type(r))
+	return r, nil
+}
+
+// DecodeFilterArgs decodes the arguments built by `FilterArgsOf` for the
+// struct t into a `FilterArgs`, whose filter can be translated to a query,
+// i.e. to SQL:
+//
+// ```
+//
+//	args, err := enc.DecodeFilterArgs(p, reflect.TypeOf(User{}))
+//	for _, c := range args.Filter.Conditions {
+//	    switch c.Operator {
+//	    case gql_auto.FilterEq:
+//	        where = append(where, c.Field+" = ?")
+//	        values = append(values, c.Value)
+//	    ...
+//	    }
+//	}
+//
+// ```
+func (enc *Encoder) DecodeFilterArgs(p graphql.ResolveParams, t reflect.Type) (FilterArgs, error) {
+	enc.mu.RLock()
+	spec, ok := enc.filters[cacheKey(t)]
+	enc.mu.RUnlock()
+	if !ok {
+		var err error
+		spec, err = build(enc, func() (*filterSpec, error) {
+			return enc.filterSpecOf(t)
+		})
+		if err != nil {
+			return FilterArgs{}, err
+		}
+	}
+	r := FilterArgs{}
+	if m, ok := p.Args["filter"].(map[string]interface{}); ok {
+		filter, err := enc.decodeFilter(spec, "filter", m)
+		if err != nil {
+			return FilterArgs{}, err
+		}
+		r.Filter = &filter
+	}
+	if values, ok := p.Args["orderBy"].([]interface{}); ok {
+		for i, value := range values {
+			order, ok := spec.orders[fmt.Sprint(value)]
+			if !ok {
+				return FilterArgs{}, NewErrDecode(indexPath("orderBy", i), fmt.Errorf("unknown order %v", value))
+			}
+			r.OrderBy = append(r.OrderBy, order)
+		}
+	}
+	for _, name := range []string{"limit", "offset"} {
+		value, ok := p.Args[name]
+		if !ok || value == nil {
+			continue
+		}
+		n, ok := value.(int)
+		if !ok || n < 0 {
+			return FilterArgs{}, NewErrDecode(name, fmt.Errorf("%v is not a non-negative integer", value))
+		}
+		if name == "limit" {
+			r.Limit = &n
+		} else {
+			r.Offset = &n
+		}
+	}
+	return r, nil
+}
+
+// decodeFilter decodes the input object m of the filter of a model.
+func (enc *Encoder) decodeFilter(spec *filterSpec, path string, m map[string]interface{}) (Filter, error) {
+	r := Filter{}
+	// The conditions follow the order of the fields of the model.
+	for _, field := range spec.fields {
+		operators, ok := m[field.name].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, operator := range []FilterOperator{FilterEq, FilterNeq, FilterIn, FilterLt, FilterGt, FilterContains, FilterIsNull} {
+			value, ok := operators[string(operator)]
+			if !ok || value == nil {
+				continue
+			}
+			condition, err := enc.decodeCondition(fieldPath(fieldPath(path, field.name), string(operator)), field, operator, value)
+			if err != nil {
+				return Filter{}, err
+			}
+			r.Conditions = append(r.Conditions, condition)
+		}
+	}
+
+	for _, name := range []string{"and", "or"} {
+		values, ok := m[name].([]interface{})
+		if !ok {
+			continue
+		}
+		filters := make([]Filter, 0, len(values))
+		for i, value := range values {
+			sub, ok := value.(map[string]interface{})
+			if !ok {
+				return Filter{}, NewErrDecode(indexPath(fieldPath(path, name), i), fmt.Errorf("cannot decode %T into a filter", value))
+			}
+			filter, err := enc.decodeFilter(spec, indexPath(fieldPath(path, name), i), sub)
+			if err != nil {
+				return Filter{}, err
+			}
+			filters = append(filters, filter)
+		}
+		if name == "and" {
+			r.And = filters
+		} else {
+			r.Or = filters
+		}
+	}
+
+	if sub, ok := m["not"].(map[string]interface{}); ok {
+		filter, err := enc.decodeFilter(spec, fieldPath(path, "not"), sub)
+		if err != nil {
+			return Filter{}, err
+		}
+		r.Not = &filter
+	}
+	return r, nil
+}
+
+// decodeCondition decodes the value of an operator of a field into the Go
+// type of the field.
+func (enc *Encoder) decodeCondition(path string, field filterField, operator FilterOperator, value interface{}) (Condition, error) {
+	r := Condition{Field: field.name, Index: field.index, Operator: operator}
+	switch operator {
+	case FilterContains, FilterIsNull:
+		r.Value = value
+		return r, nil
+	}
+
+	dst := reflect.New(field.valueType)
+	if operator == FilterIn {
+		dst = reflect.New(reflect.SliceOf(field.valueType))
+	}
+	err := enc.decodeValue(path, value, dst.Elem())
+	if err != nil {
+		return Condition{}, err
+	}
+	r.Value = dst.Elem().Interface()
+	return r, nil
+}
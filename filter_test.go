@@ -0,0 +1,185 @@
+package gql_auto_test
+
+import (
+	"database/sql"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type FilterStatus string
+
+func (FilterStatus) GraphqlEnumValues() []gql_auto.EnumValue {
+	return []gql_auto.EnumValue{{Value: "ACTIVE"}, {Value: "INACTIVE"}}
+}
+
+type FilterAddress struct {
+	City string `graphql:"city"`
+}
+
+type FilterUser struct {
+	ID       uuid.UUID      `graphql:"id"`
+	Name     string         `graphql:"name"`
+	Age      int            `graphql:"age"`
+	Score    *float64       `graphql:"score"`
+	Active   bool           `graphql:"active"`
+	Status   FilterStatus   `graphql:"status"`
+	Born     time.Time      `graphql:"born"`
+	Nickname sql.NullString `graphql:"nickname"`
+	Tags     []string       `graphql:"tags"`
+	Address  FilterAddress  `graphql:"address"`
+}
+
+func inputFieldNames(obj *graphql.InputObject) []string {
+	r := []string{}
+	for name := range obj.Fields() {
+		r = append(r, name)
+	}
+	return r
+}
+
+func TestEncoder_FilterArgsOf(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	args, err := enc.FilterArgsOf(reflect.TypeOf(FilterUser{}))
+	ass.NoError(err)
+	ass.Equal("FilterUserFilter", args["filter"].Type.String())
+	ass.Equal("[FilterUserOrderBy!]", args["orderBy"].Type.String())
+	ass.Equal("Int", args["limit"].Type.String())
+	ass.Equal("Int", args["offset"].Type.String())
+
+	filter := args["filter"].Type.(*graphql.InputObject)
+	fields := filter.Fields()
+	ass.ElementsMatch([]string{"id", "name", "age", "score", "active", "status", "born", "nickname", "and", "or", "not"}, inputFieldNames(filter))
+	ass.Equal("StringFilter", fields["id"].Type.String())
+	ass.Equal("StringFilter", fields["name"].Type.String())
+	ass.Equal("IntFilter", fields["age"].Type.String())
+	ass.Equal("FloatFilter", fields["score"].Type.String())
+	ass.Equal("BooleanFilter", fields["active"].Type.String())
+	ass.Equal("FilterStatusFilter", fields["status"].Type.String())
+	ass.Equal("DateTimeFilter", fields["born"].Type.String())
+	ass.Equal("StringFilter", fields["nickname"].Type.String())
+	ass.Equal("[FilterUserFilter!]", fields["and"].Type.String())
+	ass.Equal("FilterUserFilter", fields["not"].Type.String())
+
+	ass.ElementsMatch([]string{"eq", "neq", "in", "contains", "isNull"}, inputFieldNames(fields["name"].Type.(*graphql.InputObject)))
+	ass.ElementsMatch([]string{"eq", "neq", "in", "lt", "gt", "isNull"}, inputFieldNames(fields["age"].Type.(*graphql.InputObject)))
+	ass.ElementsMatch([]string{"eq", "neq", "isNull"}, inputFieldNames(fields["active"].Type.(*graphql.InputObject)))
+	ass.ElementsMatch([]string{"eq", "neq", "in", "isNull"}, inputFieldNames(fields["status"].Type.(*graphql.InputObject)))
+	ass.Equal("[Int!]", fields["age"].Type.(*graphql.InputObject).Fields()["in"].Type.String())
+
+	orderBy := args["orderBy"].Type.(*graphql.List).OfType.(*graphql.NonNull).OfType.(*graphql.Enum)
+	values := []string{}
+	for _, value := range orderBy.Values() {
+		values = append(values, value.Name)
+	}
+	ass.Contains(values, "AGE_DESC")
+	ass.Contains(values, "NAME_ASC")
+	ass.NotContains(values, "TAGS_ASC")
+
+	// The fields that cannot be filtered do not build input objects.
+	for _, typ := range enc.Types() {
+		ass.NotEqual("FilterAddressInput", typ.Name())
+	}
+	// The filters are listed by the types of the encoder.
+	ass.Contains(enc.Types(), graphql.Type(filter))
+	ass.Contains(enc.Types(), graphql.Type(orderBy))
+	ass.Contains(enc.PrintSDL(), "input FilterUserFilter {")
+	ass.Contains(enc.PrintSDL(), "enum FilterUserOrderBy {")
+
+	_, err = enc.FilterArgsOf(reflect.TypeOf(""))
+	ass.Error(err)
+}
+
+func TestEncoder_DecodeFilterArgs(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	args, err := enc.FilterArgsOf(reflect.TypeOf(FilterUser{}))
+	ass.NoError(err)
+	users, err := enc.TypeOf(reflect.TypeOf([]FilterUser{}))
+	ass.NoError(err)
+
+	var decoded gql_auto.FilterArgs
+	schema, err := enc.SchemaBuilder().
+		QueryField("users", graphql.Field{
+			Type: users,
+			Args: args,
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				var err error
+				decoded, err = enc.DecodeFilterArgs(p, reflect.TypeOf(FilterUser{}))
+				return []FilterUser{}, err
+			},
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			users(
+				filter: {
+					name: { contains: "a" }
+					age: { gt: 30, in: [40, 50] }
+					or: [{ status: { eq: ACTIVE } }, { nickname: { isNull: true } }]
+					not: { born: { lt: "2000-01-01T00:00:00Z" } }
+				}
+				orderBy: [AGE_DESC, NAME_ASC]
+				limit: 10
+				offset: 5
+			) { name }
+		}`,
+	})
+	ass.Empty(res.Errors)
+	ten, five := 10, 5
+	ass.Equal(gql_auto.FilterArgs{
+		Filter: &gql_auto.Filter{
+			Conditions: []gql_auto.Condition{
+				{Field: "name", Index: []int{1}, Operator: gql_auto.FilterContains, Value: "a"},
+				{Field: "age", Index: []int{2}, Operator: gql_auto.FilterIn, Value: []int{40, 50}},
+				{Field: "age", Index: []int{2}, Operator: gql_auto.FilterGt, Value: 30},
+			},
+			Or: []gql_auto.Filter{
+				{Conditions: []gql_auto.Condition{{Field: "status", Index: []int{5}, Operator: gql_auto.FilterEq, Value: FilterStatus("ACTIVE")}}},
+				{Conditions: []gql_auto.Condition{{Field: "nickname", Index: []int{7}, Operator: gql_auto.FilterIsNull, Value: true}}},
+			},
+			Not: &gql_auto.Filter{
+				Conditions: []gql_auto.Condition{{Field: "born", Index: []int{6}, Operator: gql_auto.FilterLt, Value: time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)}},
+			},
+		},
+		OrderBy: []gql_auto.Order{
+			{Field: "age", Index: []int{2}, Desc: true},
+			{Field: "name", Index: []int{1}},
+		},
+		Limit:  &ten,
+		Offset: &five,
+	}, decoded)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ users { name } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(gql_auto.FilterArgs{}, decoded)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ users(limit: -1) { name } }`,
+	})
+	ass.Len(res.Errors, 1)
+	ass.Contains(res.Errors[0].Message, "limit")
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ users(filter: { id: { eq: "not an uuid" } }) { name } }`,
+	})
+	ass.Len(res.Errors, 1)
+	ass.Contains(res.Errors[0].Message, "filter.id.eq")
+}
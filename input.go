@@ -0,0 +1,189 @@
+package gql_auto
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// inputTypeSuffix is appended to the name of a struct to name the input
+// object generated from it, i.e. Person -> PersonInput.
+const inputTypeSuffix = "Input"
+
+// InputObjectOf returns a `*graphql.InputObject` built from the struct type
+// passed.
+//
+// Nested structs are converted recursively to input objects, and slices of
+// structs become lists of input objects. The input objects are cached
+// separately from the output objects built by `StructOf`, so both can
+// coexist in the same schema.
+func (enc *Encoder) InputObjectOf(t reflect.Type) (*graphql.InputObject, error) {
+	return build(enc, func() (*graphql.InputObject, error) {
+		return enc.inputObjectOf(t)
+	})
+}
+
+func (enc *Encoder) inputObjectOf(t reflect.Type) (*graphql.InputObject, error) {
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if r, ok := enc.getInputType(t); ok {
+		if d, ok := r.(*graphql.InputObject); ok {
+			return d, nil
+		}
+		return nil, fmt.Errorf("%s is not an graphql.InputObject", r)
+	}
+
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot build an input object from a non struct")
+	}
+
+	name := enc.naming(t) + inputTypeSuffix
+	err := enc.claimName(name, t)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := graphql.InputObjectConfigFieldMap{}
+	r := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:   name,
+		Fields: fields,
+	})
+	// The input object is registered before its fields are built, so
+	// self-referencing structs resolve to the same input object.
+	enc.registerInputType(t, r)
+
+	err = enc.inputFields(t, fields)
+	if err != nil {
+		return nil, err
+	}
+	freeze(r)
+	return r, nil
+}
+
+// inputFields adds the fields of the struct t to the field map informed,
+// using the input types of each field.
+func (enc *Encoder) inputFields(t reflect.Type, r graphql.InputObjectConfigFieldMap) error {
+	for _, sf := range structFields(t, inputFieldName) {
+		field := sf.StructField
+		tag, _, err := inputFieldTag(field)
+		if err != nil {
+			return NewErrTypeNotRecognizedWithStruct(err, t, field)
+		}
+
+		var objectType graphql.Type
+		if tag.asString && isStringOptionKind(field.Type) {
+			objectType = graphql.String
+		} else if cacheKey(field.Type).Kind() == reflect.Map {
+			var strategy MapStrategy
+			strategy, err = enc.fieldMapStrategy(field)
+			if err == nil {
+				objectType, err = enc.inputMapOf(field.Type, strategy)
+			}
+		} else {
+			objectType, err = enc.buildInputFieldType(field.Type)
+		}
+		if err != nil {
+			return NewErrTypeNotRecognizedWithStruct(err, t, field)
+		}
+
+		// The fields with a default value can be omitted only when they are
+		// nullable, like the keys, which can be assigned when the values are
+		// created.
+		if !tag.key && (tag.nonNull || (!tag.nullable && !tag.hasDefault && enc.inferNonNull(field.Type))) {
+			objectType = nonNullOf(objectType)
+		}
+
+		var defaultValue interface{}
+		if tag.hasDefault {
+			defaultValue, err = parseDefaultValue(objectType, tag.defaultValue)
+			if err != nil {
+				return NewErrTypeNotRecognizedWithStruct(err, t, field)
+			}
+		}
+
+		r[sf.name] = &graphql.InputObjectFieldConfig{
+			Type:         objectType,
+			DefaultValue: defaultValue,
+			Description:  tag.description,
+		}
+	}
+	return nil
+}
+
+// buildInputFieldType returns the input type of a field. Structs are
+// converted to input objects while everything else falls back to the
+// types used by the output objects.
+func (enc *Encoder) buildInputFieldType(fieldType reflect.Type) (graphql.Type, error) {
+	if r, ok := enc.getInputType(fieldType); ok {
+		return r, nil
+	}
+
+	t := fieldType
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	// The wrappers of values that can be null are represented by the type
+	// of their values.
+	if field, ok := nullWrapperValue(t); ok && !enc.isRegisteredScalar(t) {
+		r, err := enc.buildInputFieldType(field.Type)
+		if err != nil {
+			return nil, err
+		}
+		enc.registerInputType(t, r)
+		return r, nil
+	}
+
+	// Custom and special types are the same for input and output.
+	if t == timeType || t == uuidType || t == durationType || enc.isMarshaler(t) || enc.isDriverValuer(t) ||
+		fieldType.Implements(graphqlTypedType) || reflect.PtrTo(t).Implements(graphqlTypedType) {
+		return enc.buildFieldType(fieldType)
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		return enc.inputObjectOf(t)
+	case reflect.Map:
+		return enc.inputMapOf(t, enc.mapStrategy)
+	case reflect.Array, reflect.Slice:
+		elemType, err := enc.buildInputFieldType(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		if enc.inferNonNull(t.Elem()) {
+			elemType = nonNullOf(elemType)
+		}
+		r := graphql.NewList(elemType)
+		enc.registerInputType(t, r)
+		return r, nil
+	}
+
+	r, err := enc.buildFieldType(fieldType)
+	if err != nil {
+		return nil, err
+	}
+	enc.registerInputType(fieldType, r)
+	return r, nil
+}
+
+func (enc *Encoder) getInputType(t reflect.Type) (graphql.Type, bool) {
+	gt, ok := enc.inputTypes[cacheKey(t)]
+	return gt, ok
+}
+
+func (enc *Encoder) registerInputType(t reflect.Type, r graphql.Type) {
+	setCache(enc, enc.inputTypes, cacheKey(t), r)
+}
+
+// InputObjectOf returns the `*graphql.InputObject` of the type informed built
+// by the `DefaultEncoder`.
+func InputObjectOf(t reflect.Type) *graphql.InputObject {
+	r, err := DefaultEncoder.InputObjectOf(t)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
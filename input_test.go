@@ -0,0 +1,97 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type InputAddress struct {
+	Street string `graphql:"!street"`
+	City   string `graphql:"city"`
+}
+
+type InputPerson struct {
+	Name      string         `graphql:"!name"`
+	Address   InputAddress   `graphql:"!address"`
+	Addresses []InputAddress `graphql:"addresses"`
+	Friends   []*InputPerson `graphql:"friends"`
+}
+
+func TestEncoder_ArgsOfNestedStruct(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type Args struct {
+		Person InputPerson `graphql:"person"`
+	}
+
+	args, err := gql_auto.NewEncoder().ArgsOf(reflect.TypeOf(Args{}))
+	ass.NoError(err)
+	ass.Contains(args, "person")
+
+	person, ok := args["person"].Type.(*graphql.InputObject)
+	ass.True(ok)
+	ass.Equal("InputPersonInput", person.Name())
+
+	fields := person.Fields()
+	ass.Len(fields, 4)
+	ass.Equal("String!", fields["name"].Type.String())
+	ass.Equal("InputAddressInput!", fields["address"].Type.String())
+	ass.Equal("[InputAddressInput]", fields["addresses"].Type.String())
+	ass.Equal("[InputPersonInput]", fields["friends"].Type.String())
+}
+
+func TestEncoder_InputObjectFieldMapNestedStruct(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	fields, err := gql_auto.NewEncoder().InputObjectFieldMap(reflect.TypeOf(InputPerson{}))
+	ass.NoError(err)
+	ass.Len(fields, 4)
+	ass.Equal("InputAddressInput!", fields["address"].Type.String())
+}
+
+func TestEncoder_InputObjectOfIsSeparatedFromOutput(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	obj, err := enc.StructOf(reflect.TypeOf(InputPerson{}))
+	ass.NoError(err)
+	input, err := enc.InputObjectOf(reflect.TypeOf(InputPerson{}))
+	ass.NoError(err)
+
+	ass.Equal("InputPerson", obj.Name())
+	ass.Equal("InputPersonInput", input.Name())
+	ass.Equal("InputAddress!", obj.Fields()["address"].Type.String())
+	ass.Equal("InputAddressInput!", input.Fields()["address"].Type.String())
+}
+
+func TestEncoder_ArgsOfNestedStructSchema(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type Args struct {
+		Person InputPerson `graphql:"!person"`
+	}
+
+	enc := gql_auto.NewEncoder()
+	args, err := enc.ArgsOf(reflect.TypeOf(Args{}))
+	ass.NoError(err)
+
+	_, err = graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"echo": &graphql.Field{
+					Type: graphql.String,
+					Args: args,
+				},
+			},
+		}),
+	})
+	ass.NoError(err)
+}
@@ -0,0 +1,127 @@
+package gql_auto
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Int64Policy defines how the integers that do not fit in the 32 bits of the
+// GraphQL `Int` are represented, i.e. int64, uint, uint32 and uint64.
+//
+// The type int is always represented by `Int`, since it is the integer type
+// used by most structs.
+type Int64Policy int
+
+const (
+	// Int64AsInt represents every integer by `Int`. The values out of its
+	// range are serialized as null. This is the default.
+	Int64AsInt Int64Policy = iota
+	// Int64AsLong represents the wide integers by the `LongScalar`.
+	Int64AsLong
+	// Int64AsString represents the wide integers by `String`, so they are
+	// not limited to the 53 bits of the numbers of JavaScript clients. The
+	// arguments are parsed back to the integers.
+	Int64AsString
+)
+
+// WithInt64Policy sets how the integers that do not fit in the GraphQL `Int`
+// are represented.
+func WithInt64Policy(policy Int64Policy) EncoderOption {
+	return func(enc *Encoder) {
+		enc.int64Policy = policy
+	}
+}
+
+// isWideInt checks if the integers of the kind k can be out of the range of
+// the GraphQL `Int`.
+func isWideInt(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int64, reflect.Uint, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// intType returns the GraphQL type of the integers of the kind k by the
+// policy of the encoder.
+func (enc *Encoder) intType(k reflect.Kind) graphql.Type {
+	if !isWideInt(k) {
+		return graphql.Int
+	}
+	switch enc.int64Policy {
+	case Int64AsLong:
+		return LongScalar
+	case Int64AsString:
+		return graphql.String
+	}
+	return graphql.Int
+}
+
+// LongScalar represents 64-bit integers, signed and unsigned, by numbers.
+// Strings with integers are also accepted as input.
+var LongScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Long",
+	Description: "The `Long` scalar type represents a 64-bit integer, signed or unsigned.",
+	Serialize: func(value interface{}) interface{} {
+		value, ok := derefValue(value)
+		if !ok {
+			return nil
+		}
+		v := reflect.ValueOf(value)
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return v.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return v.Uint()
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		switch value := value.(type) {
+		case int, int64, uint64:
+			return value
+		case float64:
+			if value != math.Trunc(value) || value < math.MinInt64 || value >= math.MaxInt64 {
+				return nil
+			}
+			return int64(value)
+		case json.Number:
+			return parseLong(value.String())
+		case string:
+			return parseLong(value)
+		}
+		return nil
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		switch value := valueAST.(type) {
+		case *ast.IntValue:
+			return parseLong(value.Value)
+		case *ast.StringValue:
+			return parseLong(value.Value)
+		}
+		return nil
+	},
+})
+
+// parseLong parses a signed or unsigned 64-bit integer. It returns nil when
+// s is not an integer in the range.
+func parseLong(s string) interface{} {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return n
+	}
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return n
+	}
+	return nil
+}
+
+// errOverflow is the error of a number that does not fit in the Go type t.
+func errOverflow(v interface{}, t reflect.Type) error {
+	return fmt.Errorf("%v overflows %s", v, t)
+}
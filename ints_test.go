@@ -0,0 +1,174 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+type IntsCounter struct {
+	Small  int32  `graphql:"small"`
+	Count  int    `graphql:"count"`
+	ID     int64  `graphql:"id"`
+	Hits   uint64 `graphql:"hits"`
+	Unsign uint32 `graphql:"unsign"`
+}
+
+type IntsArgs struct {
+	Small int8    `graphql:"small"`
+	ID    int64   `graphql:"id"`
+	Hits  uint64  `graphql:"hits"`
+	Ratio float32 `graphql:"ratio"`
+}
+
+type IntsComplex struct {
+	Value complex128 `graphql:"value"`
+}
+
+func intsSchema(t *testing.T, enc *gql_auto.Encoder, decoded *IntsArgs) graphql.Schema {
+	schema, err := enc.SchemaBuilder().
+		Query("counter", IntsArgs{}, IntsCounter{}, func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, decoded)
+			return IntsCounter{Small: 1, Count: 2, ID: math.MaxInt64, Hits: math.MaxUint64, Unsign: math.MaxUint32}, err
+		}).
+		Build()
+	assert.NoError(t, err)
+	return schema
+}
+
+func TestEncoder_Int64Policy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	obj, err := gql_auto.NewEncoder().Struct(IntsCounter{})
+	ass.NoError(err)
+	ass.Equal(graphql.Int, obj.Fields()["id"].Type)
+
+	obj, err = gql_auto.NewEncoder(gql_auto.WithInt64Policy(gql_auto.Int64AsLong)).Struct(IntsCounter{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal(graphql.Int, fields["small"].Type)
+	ass.Equal(graphql.Int, fields["count"].Type)
+	ass.Equal(gql_auto.LongScalar, fields["id"].Type)
+	ass.Equal(gql_auto.LongScalar, fields["hits"].Type)
+	ass.Equal(gql_auto.LongScalar, fields["unsign"].Type)
+
+	obj, err = gql_auto.NewEncoder(gql_auto.WithInt64Policy(gql_auto.Int64AsString)).Struct(IntsCounter{})
+	ass.NoError(err)
+	ass.Equal(graphql.String, obj.Fields()["id"].Type)
+	ass.Equal(graphql.Int, obj.Fields()["small"].Type)
+
+	_, err = gql_auto.NewEncoder().Struct(IntsComplex{})
+	ass.Error(err)
+}
+
+func TestEncoder_Int64AsLong(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	var decoded IntsArgs
+	schema := intsSchema(t, gql_auto.NewEncoder(gql_auto.WithInt64Policy(gql_auto.Int64AsLong)), &decoded)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ counter(id: 9223372036854775807, hits: "18446744073709551615", small: 7) { small count id hits unsign } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(IntsArgs{Small: 7, ID: math.MaxInt64, Hits: math.MaxUint64}, decoded)
+	ass.Equal(map[string]interface{}{
+		"counter": map[string]interface{}{
+			"small":  1,
+			"count":  2,
+			"id":     int64(math.MaxInt64),
+			"hits":   uint64(math.MaxUint64),
+			"unsign": uint64(math.MaxUint32),
+		},
+	}, res.Data)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ counter(id: 18446744073709551615) { id } }`,
+	})
+	ass.Len(res.Errors, 1)
+	ass.Contains(res.Errors[0].Message, "id: 18446744073709551615 overflows int64")
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ counter(hits: -1) { id } }`,
+	})
+	ass.Len(res.Errors, 1)
+}
+
+func TestEncoder_Int64AsString(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	var decoded IntsArgs
+	schema := intsSchema(t, gql_auto.NewEncoder(gql_auto.WithInt64Policy(gql_auto.Int64AsString)), &decoded)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ counter(id: "-9223372036854775808", hits: "18446744073709551615") { id hits unsign } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(IntsArgs{ID: math.MinInt64, Hits: math.MaxUint64}, decoded)
+	ass.Equal(map[string]interface{}{
+		"counter": map[string]interface{}{
+			"id":     "9223372036854775807",
+			"hits":   "18446744073709551615",
+			"unsign": "4294967295",
+		},
+	}, res.Data)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ counter(id: "9223372036854775808") { id } }`,
+	})
+	ass.Len(res.Errors, 1)
+}
+
+func TestDecoder_RangeCheck(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	var decoded IntsArgs
+	schema := intsSchema(t, gql_auto.NewEncoder(), &decoded)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ counter(small: 300) { count } }`,
+	})
+	ass.Len(res.Errors, 1)
+	ass.Contains(res.Errors[0].Message, "small: 300 overflows int8")
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ counter(ratio: 1e300) { count } }`,
+	})
+	ass.Len(res.Errors, 1)
+	ass.Contains(res.Errors[0].Message, "overflows float32")
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ counter(small: -128, ratio: 0.5) { count } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(IntsArgs{Small: -128, Ratio: 0.5}, decoded)
+}
+
+func TestEncoder_DecodeFloatBounds(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+
+	var decoded IntsArgs
+	ass.NoError(enc.Decode(map[string]interface{}{"id": -math.Pow(2, 63)}, &decoded))
+	ass.Equal(int64(math.MinInt64), decoded.ID)
+
+	// 2^63 is the float64 nearest to math.MaxInt64, but it overflows int64.
+	ass.Error(enc.Decode(map[string]interface{}{"id": math.Pow(2, 63)}, &decoded))
+	ass.Error(enc.Decode(map[string]interface{}{"hits": math.Pow(2, 63)}, &decoded))
+}
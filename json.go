@@ -0,0 +1,215 @@
+package gql_auto
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+)
+
+var (
+	jsonMarshalerType   = reflect.TypeOf(new(json.Marshaler)).Elem()
+	jsonUnmarshalerType = reflect.TypeOf(new(json.Unmarshaler)).Elem()
+	textMarshalerType   = reflect.TypeOf(new(encoding.TextMarshaler)).Elem()
+)
+
+// WithMarshalerScalar represents the types implementing `json.Marshaler` or
+// `encoding.TextMarshaler` by the scalar informed, i.e. `JSON` or
+// `graphql.String`, instead of building them from their Go kind.
+//
+// The fields of those types resolve to the marshaled value: the value
+// decoded from the output of `MarshalJSON`, or the string returned by
+// `MarshalText`. The arguments are decoded back by `UnmarshalJSON` or
+// `UnmarshalText`.
+func WithMarshalerScalar(scalar *graphql.Scalar) EncoderOption {
+	return func(enc *Encoder) {
+		enc.marshalerScalar = scalar
+	}
+}
+
+// isMarshaler checks if the type t, or a pointer to it, is represented by
+// the marshaler scalar of the encoder. The types with their own GraphQL type,
+// like enums or time.Time, are not.
+func (enc *Encoder) isMarshaler(t reflect.Type) bool {
+	if enc.marshalerScalar == nil {
+		return false
+	}
+	t = cacheKey(t)
+	if r, ok := enc.getType(t); ok {
+		return r == enc.marshalerScalar
+	}
+	pt := reflect.PtrTo(t)
+	if t == timeType || t == uuidType || isGraphqlEnum(t) ||
+		t.Implements(graphqlTypedType) || pt.Implements(graphqlTypedType) {
+		return false
+	}
+	return pt.Implements(jsonMarshalerType) || pt.Implements(textMarshalerType)
+}
+
+// holdsMarshaler checks if the values of the type t, or the elements of its
+// lists, are represented by the marshaler scalar of the encoder.
+func (enc *Encoder) holdsMarshaler(t reflect.Type) bool {
+	for {
+		if enc.isMarshaler(t) {
+			return true
+		}
+		switch t.Kind() {
+		case reflect.Ptr, reflect.Slice, reflect.Array:
+			t = t.Elem()
+		default:
+			return false
+		}
+	}
+}
+
+// marshalerResolve returns the resolver of a field holding marshalers.
+func marshalerResolve(r fieldReader) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v, ok, err := r.read(p)
+		if !ok {
+			return nil, err
+		}
+		return marshalValue(v)
+	}
+}
+
+// marshalValue returns the marshaled value of v, converting the elements of
+// lists one by one.
+func marshalValue(v reflect.Value) (interface{}, error) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil, nil
+		}
+		v = v.Elem()
+	}
+
+	switch m := addressableCopy(v).Interface().(type) {
+	case json.Marshaler:
+		b, err := m.MarshalJSON()
+		if err != nil {
+			return nil, err
+		}
+		var r interface{}
+		err = json.Unmarshal(b, &r)
+		return r, err
+	case encoding.TextMarshaler:
+		b, err := m.MarshalText()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	}
+
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil, nil
+		}
+		r := make([]interface{}, v.Len())
+		for i := range r {
+			item, err := marshalValue(v.Index(i))
+			if err != nil {
+				return nil, err
+			}
+			r[i] = item
+		}
+		return r, nil
+	}
+	return v.Interface(), nil
+}
+
+// decodeJSONUnmarshaler sets dst, which implements `json.Unmarshaler`, with
+// the JSON representation of src.
+func decodeJSONUnmarshaler(path string, src interface{}, dst reflect.Value) error {
+	b, err := json.Marshal(src)
+	if err != nil {
+		return NewErrDecode(path, err)
+	}
+	err = dst.Addr().Interface().(json.Unmarshaler).UnmarshalJSON(b)
+	if err != nil {
+		return NewErrDecode(path, err)
+	}
+	return nil
+}
+
+// isStringOptionKind checks if the ",string" option of the json tag applies
+// to the type t, which is true for strings, numbers and booleans.
+func isStringOptionKind(t reflect.Type) bool {
+	switch cacheKey(t).Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	}
+	return false
+}
+
+// stringOptionResolve returns the resolver of a field with the ",string"
+// option, which formats the value as a string.
+func stringOptionResolve(r fieldReader) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v, ok, err := r.read(p)
+		if !ok {
+			return nil, err
+		}
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, nil
+			}
+			v = v.Elem()
+		}
+		return fmt.Sprint(v.Interface()), nil
+	}
+}
+
+// decodeStringOption sets dst, a field with the ",string" option, with the
+// string src.
+func decodeStringOption(path string, src interface{}, dst reflect.Value) error {
+	if src == nil {
+		dst.Set(reflect.Zero(dst.Type()))
+		return nil
+	}
+	s, ok := src.(string)
+	if !ok {
+		return NewErrDecode(path, fmt.Errorf("cannot decode %T into %s", src, dst.Type()))
+	}
+	if dst.Kind() == reflect.Ptr {
+		elem := reflect.New(dst.Type().Elem())
+		err := decodeStringOption(path, src, elem.Elem())
+		if err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	}
+
+	var err error
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Bool:
+		var b bool
+		b, err = strconv.ParseBool(s)
+		dst.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var n int64
+		n, err = strconv.ParseInt(s, 10, dst.Type().Bits())
+		dst.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		var n uint64
+		n, err = strconv.ParseUint(s, 10, dst.Type().Bits())
+		dst.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		var n float64
+		n, err = strconv.ParseFloat(s, dst.Type().Bits())
+		dst.SetFloat(n)
+	}
+	if err != nil {
+		return NewErrDecode(path, err)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package gql_auto_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type JSONTagged struct {
+	ID       int64   `json:"id,string"`
+	Score    *int    `json:"score,string,omitempty"`
+	Title    string  `json:",omitempty"`
+	Internal string  `json:"-"`
+	Rating   float64 `json:"rating"`
+}
+
+// JSONColor is represented by its text.
+type JSONColor struct {
+	R, G, B uint8
+}
+
+func (c JSONColor) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)), nil
+}
+
+func (c *JSONColor) UnmarshalText(text []byte) error {
+	_, err := fmt.Sscanf(string(text), "#%02x%02x%02x", &c.R, &c.G, &c.B)
+	return err
+}
+
+// JSONSettings is represented by its JSON.
+type JSONSettings struct {
+	values map[string]interface{}
+}
+
+func (s *JSONSettings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.values)
+}
+
+func (s *JSONSettings) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &s.values)
+}
+
+type JSONTheme struct {
+	Name     string       `json:"name"`
+	Color    JSONColor    `json:"color"`
+	Palette  []JSONColor  `json:"palette"`
+	Settings JSONSettings `json:"settings"`
+}
+
+func TestEncoder_JSONTags(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	obj, err := enc.Struct(JSONTagged{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Len(fields, 4)
+	ass.Equal("String", fields["id"].Type.String())
+	ass.Equal("String", fields["score"].Type.String())
+	ass.Equal("String", fields["title"].Type.String())
+	ass.Equal("Float", fields["rating"].Type.String())
+
+	args, err := enc.ArgsOf(reflect.TypeOf(JSONTagged{}))
+	ass.NoError(err)
+	ass.Len(args, 4)
+	ass.Equal("String", args["id"].Type.String())
+	ass.Equal("String", args["score"].Type.String())
+	ass.Contains(args, "title")
+	ass.Contains(args, "rating")
+
+	var decoded JSONTagged
+	schema, err := enc.SchemaBuilder().
+		Query("tagged", JSONTagged{}, JSONTagged{}, func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, &decoded)
+			return decoded, err
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ tagged(id: "9007199254740993", score: "7", rating: 4.5) { id score title rating } }`,
+	})
+	ass.Empty(res.Errors)
+	score := 7
+	ass.Equal(JSONTagged{ID: 9007199254740993, Score: &score, Rating: 4.5}, decoded)
+	ass.Equal(map[string]interface{}{
+		"tagged": map[string]interface{}{
+			"id":     "9007199254740993",
+			"score":  "7",
+			"title":  "",
+			"rating": 4.5,
+		},
+	}, res.Data)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ tagged(id: "x") { id } }`,
+	})
+	ass.Len(res.Errors, 1)
+	ass.Equal(`id: strconv.ParseInt: parsing "x": invalid syntax`, res.Errors[0].Message)
+}
+
+func TestEncoder_WithMarshalerScalar(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder(gql_auto.WithMarshalerScalar(gql_auto.JSON))
+	obj, err := enc.Struct(JSONTheme{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal("JSON", fields["color"].Type.String())
+	ass.Equal("[JSON]", fields["palette"].Type.String())
+	ass.Equal("JSON", fields["settings"].Type.String())
+
+	var decoded JSONTheme
+	schema, err := enc.SchemaBuilder().
+		Query("theme", JSONTheme{}, JSONTheme{}, func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, &decoded)
+			return &decoded, err
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			theme(name: "dark", color: "#102030", palette: ["#000000"], settings: {compact: true}) {
+				name color palette settings
+			}
+		}`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(JSONColor{R: 0x10, G: 0x20, B: 0x30}, decoded.Color)
+	ass.Equal(map[string]interface{}{
+		"theme": map[string]interface{}{
+			"name":     "dark",
+			"color":    "#102030",
+			"palette":  []interface{}{"#000000"},
+			"settings": map[string]interface{}{"compact": true},
+		},
+	}, res.Data)
+
+	// Without the option, the structs are objects, and JSONSettings has no
+	// fields.
+	obj, err = gql_auto.NewEncoder().Struct(JSONColor{})
+	ass.NoError(err)
+	ass.Equal("JSONColor", obj.Name())
+	_, err = gql_auto.NewEncoder().Struct(JSONTheme{})
+	ass.ErrorContains(err, "JSONSettings has no fields")
+}
@@ -0,0 +1,418 @@
+package gql_auto
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// MapStrategy defines how Go maps are represented in the schema.
+type MapStrategy int
+
+const (
+	// MapAsJSON represents maps by the `JSON` scalar. This is the default
+	// strategy.
+	MapAsJSON MapStrategy = iota
+	// MapAsEntries represents maps by a list of objects with the fields key
+	// and value, typed from the key and value types of the map, i.e.
+	// map[string]int -> [StringIntEntry].
+	MapAsEntries
+)
+
+// mapTag is the companion tag that selects the strategy of a map field,
+// overriding the strategy of the encoder. It accepts "json" and "entries".
+const mapTag = "gqlmap"
+
+// JSON is the scalar that represents arbitrary JSON values, it is used to
+// represent maps by the `MapAsJSON` strategy.
+var JSON = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "The `JSON` scalar type represents arbitrary JSON values.",
+	Serialize: func(value interface{}) interface{} {
+		return value
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return value
+	},
+	ParseLiteral: parseJSONLiteral,
+})
+
+// parseJSONLiteral converts a literal of the query to a Go value.
+func parseJSONLiteral(valueAST ast.Value) interface{} {
+	switch value := valueAST.(type) {
+	case *ast.StringValue:
+		return value.Value
+	case *ast.BooleanValue:
+		return value.Value
+	case *ast.EnumValue:
+		return value.Value
+	case *ast.IntValue:
+		n, err := strconv.Atoi(value.Value)
+		if err != nil {
+			return nil
+		}
+		return n
+	case *ast.FloatValue:
+		n, err := strconv.ParseFloat(value.Value, 64)
+		if err != nil {
+			return nil
+		}
+		return n
+	case *ast.ListValue:
+		r := make([]interface{}, 0, len(value.Values))
+		for _, v := range value.Values {
+			r = append(r, parseJSONLiteral(v))
+		}
+		return r
+	case *ast.ObjectValue:
+		r := make(map[string]interface{}, len(value.Fields))
+		for _, field := range value.Fields {
+			r[field.Name.Value] = parseJSONLiteral(field.Value)
+		}
+		return r
+	}
+	return nil
+}
+
+// WithMapStrategy sets the strategy used to represent Go maps. It can be
+// overridden by field with the "gqlmap" tag:
+//
+// ```
+//
+//	type T struct {
+//	    Labels map[string]string `graphql:"labels" gqlmap:"entries"`
+//	}
+//
+// ```
+func WithMapStrategy(strategy MapStrategy) EncoderOption {
+	return func(enc *Encoder) {
+		enc.mapStrategy = strategy
+	}
+}
+
+// fieldMapStrategy returns the strategy of a map field, which is the strategy
+// of the encoder unless the field sets it with the "gqlmap" tag.
+func (enc *Encoder) fieldMapStrategy(field reflect.StructField) (MapStrategy, error) {
+	tag, ok := field.Tag.Lookup(mapTag)
+	if !ok {
+		return enc.mapStrategy, nil
+	}
+	switch tag {
+	case "json":
+		return MapAsJSON, nil
+	case "entries":
+		return MapAsEntries, nil
+	}
+	return MapAsJSON, fmt.Errorf("unknown map strategy '%s'", tag)
+}
+
+// mapEntry is the source of the entry objects.
+type mapEntry struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// mapOf returns the output type of the map type t.
+func (enc *Encoder) mapOf(t reflect.Type, strategy MapStrategy) (graphql.Type, error) {
+	t = cacheKey(t)
+	if strategy == MapAsJSON {
+		return JSON, nil
+	}
+
+	keyType, err := enc.buildFieldType(t.Key())
+	if err != nil {
+		return nil, err
+	}
+	valueType, err := enc.mapValueType(t.Elem(), enc.buildFieldType)
+	if err != nil {
+		return nil, err
+	}
+
+	name := entryName(keyType, valueType)
+	if r, ok := enc.entries[name]; ok {
+		return graphql.NewList(r), nil
+	}
+	err = enc.claimName(name, t)
+	if err != nil {
+		return nil, err
+	}
+	r := enc.newObject(graphql.ObjectConfig{
+		Name: name,
+		Fields: graphql.Fields{
+			"key": &graphql.Field{
+				Type: graphql.NewNonNull(keyType),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(mapEntry).Key, nil
+				},
+			},
+			"value": &graphql.Field{
+				Type: valueType,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(mapEntry).Value, nil
+				},
+			},
+		},
+	})
+	freeze(r)
+	setCache(enc, enc.entries, name, graphql.Type(r))
+	return graphql.NewList(r), nil
+}
+
+// inputMapOf returns the input type of the map type t.
+func (enc *Encoder) inputMapOf(t reflect.Type, strategy MapStrategy) (graphql.Type, error) {
+	t = cacheKey(t)
+	if strategy == MapAsJSON {
+		return JSON, nil
+	}
+
+	keyType, err := enc.buildInputFieldType(t.Key())
+	if err != nil {
+		return nil, err
+	}
+	valueType, err := enc.mapValueType(t.Elem(), enc.buildInputFieldType)
+	if err != nil {
+		return nil, err
+	}
+
+	name := entryName(keyType, valueType) + inputTypeSuffix
+	if r, ok := enc.entries[name]; ok {
+		return graphql.NewList(r), nil
+	}
+	err = enc.claimName(name, t)
+	if err != nil {
+		return nil, err
+	}
+	r := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: name,
+		Fields: graphql.InputObjectConfigFieldMap{
+			"key": &graphql.InputObjectFieldConfig{
+				Type: graphql.NewNonNull(keyType),
+			},
+			"value": &graphql.InputObjectFieldConfig{
+				Type: valueType,
+			},
+		},
+	})
+	freeze(r)
+	setCache(enc, enc.entries, name, graphql.Type(r))
+	return graphql.NewList(r), nil
+}
+
+// mapValueType returns the type of the values of a map. Maps of empty
+// interfaces hold arbitrary values, so they are represented by `JSON`.
+func (enc *Encoder) mapValueType(t reflect.Type, build func(reflect.Type) (graphql.Type, error)) (graphql.Type, error) {
+	if t.Kind() == reflect.Interface && t.NumMethod() == 0 {
+		return JSON, nil
+	}
+	return build(t)
+}
+
+// entryName returns the name of the entry objects of a map with the key and
+// value types informed, i.e. StringIntEntry.
+func entryName(keyType graphql.Type, valueType graphql.Type) string {
+	return typeNamePart(keyType) + typeNamePart(valueType) + "Entry"
+}
+
+func typeNamePart(t graphql.Type) string {
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return typeNamePart(t.OfType)
+	case *graphql.List:
+		return typeNamePart(t.OfType) + "List"
+	}
+	return t.Name()
+}
+
+// mapEntriesUnwrap returns the function that converts the maps held by the
+// values of the type t to the entries of the `MapAsEntries` strategy, in
+// pointers, lists and values of other maps. strategy is the strategy of the
+// maps of type t, the nested maps follow the strategy of the encoder. It
+// returns nil when the values of t are not converted.
+func (enc *Encoder) mapEntriesUnwrap(t reflect.Type, strategy MapStrategy) func(v reflect.Value) interface{} {
+	switch t.Kind() {
+	case reflect.Ptr:
+		elem := enc.mapEntriesUnwrap(t.Elem(), strategy)
+		if elem == nil {
+			return nil
+		}
+		return func(v reflect.Value) interface{} {
+			if v.IsNil() {
+				return nil
+			}
+			return elem(v.Elem())
+		}
+	case reflect.Slice, reflect.Array:
+		elem := enc.mapEntriesUnwrap(t.Elem(), enc.mapStrategy)
+		if elem == nil {
+			return nil
+		}
+		return func(v reflect.Value) interface{} {
+			if v.Kind() == reflect.Slice && v.IsNil() {
+				return nil
+			}
+			r := make([]interface{}, v.Len())
+			for i := range r {
+				r[i] = elem(v.Index(i))
+			}
+			return r
+		}
+	case reflect.Map:
+		if strategy != MapAsEntries {
+			return nil
+		}
+		value := enc.mapEntriesUnwrap(t.Elem(), enc.mapStrategy)
+		return func(v reflect.Value) interface{} {
+			if v.IsNil() {
+				return nil
+			}
+			return mapEntries(v, value)
+		}
+	}
+	return nil
+}
+
+// mapEntriesResolve converts the maps held by the values of the type t
+// returned by the resolver next to entries. The resolver is returned as is
+// when the values of t are not converted.
+func (enc *Encoder) mapEntriesResolve(t reflect.Type, strategy MapStrategy, next graphql.FieldResolveFn) graphql.FieldResolveFn {
+	unwrap := enc.mapEntriesUnwrap(t, strategy)
+	if unwrap == nil {
+		return next
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v, err := next(p)
+		if err != nil || v == nil {
+			return v, err
+		}
+		// Custom resolvers can return values of other types.
+		rv := reflect.ValueOf(v)
+		if rv.Type() != t {
+			return v, nil
+		}
+		return unwrap(rv), nil
+	}
+}
+
+// mapEntries returns the entries of the map v sorted by key. The values are
+// converted by value, unless it is nil.
+func mapEntries(v reflect.Value, value func(v reflect.Value) interface{}) []mapEntry {
+	keys := make([]reflect.Value, 0, v.Len())
+	values := make([]reflect.Value, 0, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		keys = append(keys, iter.Key())
+		values = append(values, iter.Value())
+	}
+	order := make([]int, len(keys))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		return lessKey(keys[order[i]], keys[order[j]])
+	})
+
+	r := make([]mapEntry, 0, len(keys))
+	for _, i := range order {
+		entry := mapEntry{Key: keys[i].Interface()}
+		if value != nil {
+			entry.Value = value(values[i])
+		} else {
+			entry.Value = values[i].Interface()
+		}
+		r = append(r, entry)
+	}
+	return r
+}
+
+// lessKey checks if the map key a comes before b. The numbers are sorted by
+// value, and the keys of other kinds by their text.
+func lessKey(a reflect.Value, b reflect.Value) bool {
+	switch a.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() < b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() < b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() < b.Float()
+	case reflect.String:
+		return a.String() < b.String()
+	}
+	return fmt.Sprint(a.Interface()) < fmt.Sprint(b.Interface())
+}
+
+// decodeMap sets the map dst with src, which is either an object, when the
+// map is represented by `JSON`, or a list of entries.
+func (enc *Encoder) decodeMap(path string, src interface{}, dst reflect.Value) error {
+	t := dst.Type()
+	r := reflect.MakeMap(t)
+
+	set := func(path string, key interface{}, value interface{}) error {
+		k := reflect.New(t.Key()).Elem()
+		err := enc.decodeMapKey(path, key, k)
+		if err != nil {
+			return err
+		}
+		v := reflect.New(t.Elem()).Elem()
+		err = enc.decodeValue(fieldPath(path, fmt.Sprint(key)), value, v)
+		if err != nil {
+			return err
+		}
+		r.SetMapIndex(k, v)
+		return nil
+	}
+
+	switch src := src.(type) {
+	case map[string]interface{}:
+		for key, value := range src {
+			err := set(path, key, value)
+			if err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		for i, item := range src {
+			entry, ok := item.(map[string]interface{})
+			if !ok {
+				return NewErrDecode(indexPath(path, i), fmt.Errorf("cannot decode %T into a map entry", item))
+			}
+			err := set(path, entry["key"], entry["value"])
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return NewErrDecode(path, fmt.Errorf("cannot decode %T into %s", src, t))
+	}
+	dst.Set(r)
+	return nil
+}
+
+// decodeMapKey sets the key dst. Keys of objects are always strings, so they
+// are parsed when the key of the map is a number.
+func (enc *Encoder) decodeMapKey(path string, key interface{}, dst reflect.Value) error {
+	s, ok := key.(string)
+	if !ok {
+		return enc.decodeValue(path, key, dst)
+	}
+	switch dst.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, dst.Type().Bits())
+		if err != nil {
+			return NewErrDecode(path, err)
+		}
+		dst.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, dst.Type().Bits())
+		if err != nil {
+			return NewErrDecode(path, err)
+		}
+		dst.SetUint(n)
+		return nil
+	}
+	return enc.decodeValue(path, key, dst)
+}
@@ -0,0 +1,198 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type MapResource struct {
+	Name     string                 `graphql:"!name"`
+	Labels   map[string]string      `graphql:"labels" gqlmap:"entries"`
+	Counts   map[int]int            `graphql:"counts" gqlmap:"entries"`
+	Metadata map[string]interface{} `graphql:"metadata"`
+}
+
+type MapResourceArgs struct {
+	Labels   map[string]string      `graphql:"labels" gqlmap:"entries"`
+	Metadata map[string]interface{} `graphql:"metadata"`
+}
+
+func TestEncoder_Maps(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	obj, err := enc.Struct(MapResource{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal("[StringStringEntry]", fields["labels"].Type.String())
+	ass.Equal("[IntIntEntry]", fields["counts"].Type.String())
+	ass.Equal(gql_auto.JSON, fields["metadata"].Type)
+
+	args, err := enc.ArgsOf(reflect.TypeOf(MapResourceArgs{}))
+	ass.NoError(err)
+	ass.Equal("[StringStringEntryInput]", args["labels"].Type.String())
+	ass.Equal(gql_auto.JSON, args["metadata"].Type)
+
+	var decoded MapResourceArgs
+	schema, err := enc.SchemaBuilder().
+		Query("resource", MapResourceArgs{}, MapResource{}, func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, &decoded)
+			return &MapResource{
+				Name:     "r",
+				Labels:   decoded.Labels,
+				Counts:   map[int]int{2: 20, 10: 100, -1: -10},
+				Metadata: decoded.Metadata,
+			}, err
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			resource(
+				labels: [{key: "b", value: "2"}, {key: "a", value: "1"}],
+				metadata: {owner: "duke", tags: ["x", "y"], size: 3}
+			) {
+				labels { key value }
+				counts { key value }
+				metadata
+			}
+		}`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]string{"a": "1", "b": "2"}, decoded.Labels)
+	ass.Equal(map[string]interface{}{
+		"owner": "duke",
+		"tags":  []interface{}{"x", "y"},
+		"size":  3,
+	}, decoded.Metadata)
+	ass.Equal(map[string]interface{}{
+		"resource": map[string]interface{}{
+			"labels": []interface{}{
+				map[string]interface{}{"key": "a", "value": "1"},
+				map[string]interface{}{"key": "b", "value": "2"},
+			},
+			"counts": []interface{}{
+				map[string]interface{}{"key": -1, "value": -10},
+				map[string]interface{}{"key": 2, "value": 20},
+				map[string]interface{}{"key": 10, "value": 100},
+			},
+			"metadata": map[string]interface{}{
+				"owner": "duke",
+				"tags":  []interface{}{"x", "y"},
+				"size":  3,
+			},
+		},
+	}, res.Data)
+}
+
+func TestEncoder_WithMapStrategy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	type Example struct {
+		Labels   map[string]string `graphql:"labels"`
+		Override map[string]string `graphql:"override" gqlmap:"json"`
+		Invalid  map[string]string `graphql:"invalid" gqlmap:"other"`
+	}
+	type Valid struct {
+		Labels   map[string]string `graphql:"labels"`
+		Override map[string]string `graphql:"override" gqlmap:"json"`
+	}
+
+	enc := gql_auto.NewEncoder(gql_auto.WithMapStrategy(gql_auto.MapAsEntries))
+	obj, err := enc.Struct(Valid{})
+	ass.NoError(err)
+	ass.Equal("[StringStringEntry]", obj.Fields()["labels"].Type.String())
+	ass.Equal(gql_auto.JSON, obj.Fields()["override"].Type)
+
+	_, err = enc.Struct(Example{})
+	ass.ErrorContains(err, "unknown map strategy 'other'")
+}
+
+type MapNested struct {
+	Labels []map[string]int          `graphql:"labels"`
+	Groups map[string]map[string]int `graphql:"groups"`
+	Scores *map[string]int           `graphql:"scores"`
+}
+
+func (MapNested) ResolveTotals() []map[string]int {
+	return []map[string]int{{"b": 2, "a": 1}}
+}
+
+func TestEncoder_NestedMapEntries(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder(gql_auto.WithMapStrategy(gql_auto.MapAsEntries))
+	obj, err := enc.Struct(MapNested{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal("[[StringIntEntry]]", fields["labels"].Type.String())
+	ass.Equal("[StringStringIntEntryListEntry]", fields["groups"].Type.String())
+	ass.Equal("[[StringIntEntry]]", fields["totals"].Type.String())
+
+	scores := map[string]int{"x": 1}
+	schema, err := enc.SchemaBuilder().
+		Query("nested", nil, MapNested{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return MapNested{
+				Labels: []map[string]int{{"a": 1}, nil},
+				Groups: map[string]map[string]int{"g": {"b": 2}},
+				Scores: &scores,
+			}, nil
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			nested {
+				labels { key value }
+				groups { key value { key value } }
+				scores { key value }
+				totals { key value }
+			}
+		}`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"nested": map[string]interface{}{
+			"labels": []interface{}{
+				[]interface{}{map[string]interface{}{"key": "a", "value": 1}},
+				nil,
+			},
+			"groups": []interface{}{
+				map[string]interface{}{
+					"key":   "g",
+					"value": []interface{}{map[string]interface{}{"key": "b", "value": 2}},
+				},
+			},
+			"scores": []interface{}{map[string]interface{}{"key": "x", "value": 1}},
+			"totals": []interface{}{
+				[]interface{}{
+					map[string]interface{}{"key": "a", "value": 1},
+					map[string]interface{}{"key": "b", "value": 2},
+				},
+			},
+		},
+	}, res.Data)
+}
+
+func TestEncoder_DecodeMapKeys(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	var decoded map[int]string
+	err := gql_auto.NewEncoder().Decode(map[string]interface{}{"1": "a", "2": "b"}, &decoded)
+	ass.NoError(err)
+	ass.Equal(map[int]string{1: "a", 2: "b"}, decoded)
+
+	err = gql_auto.NewEncoder().Decode(map[string]interface{}{"x": "a"}, &decoded)
+	ass.Error(err)
+}
@@ -0,0 +1,413 @@
+package gql_auto
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore is a `Repository` that keeps the values of the struct T in
+// memory. It is safe for concurrent use, and it is meant to serve schemas
+// built by `CRUD` in tests and prototypes:
+//
+// ```
+//
+//	type Person struct {
+//	    ID   string `graphql:"!id,key"`
+//	    Name string `graphql:"!name"`
+//	}
+//
+//	store, err := gql_auto.NewMemoryStore[Person]()
+//	schema, err := enc.SchemaBuilder().
+//	    CRUD(gql_auto.CRUD[Person](store)).
+//	    Build()
+//
+// ```
+//
+// The values are identified by the field whose tag has the "key" option.
+// The keys of the values created without them are assigned by the store
+// when they are strings or integers.
+type MemoryStore[T any] struct {
+	mu     sync.RWMutex
+	key    structField
+	values []T
+	lastID int64
+}
+
+var _ Repository[struct{}] = (*MemoryStore[struct{}])(nil)
+
+// NewMemoryStore returns a `MemoryStore` with the values informed.
+func NewMemoryStore[T any](values ...T) (*MemoryStore[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot store %s in memory, it is not a struct", t)
+	}
+	key, err := keyField(t)
+	if err != nil {
+		return nil, err
+	}
+	r := &MemoryStore[T]{key: key}
+	for _, value := range values {
+		_, err := r.Create(context.Background(), value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// keyField returns the field of the struct t whose tag has the "key" option.
+func keyField(t reflect.Type) (structField, error) {
+	var r []structField
+	for _, sf := range structFields(t, outputFieldName) {
+		tag, err := parseFieldTag(sf.StructField)
+		if err != nil {
+			return structField{}, NewErrTypeNotRecognizedWithStruct(err, t, sf.StructField)
+		}
+		if tag.key {
+			r = append(r, sf)
+		}
+	}
+	if len(r) != 1 {
+		return structField{}, fmt.Errorf("%s must have one key field, it has %d", t, len(r))
+	}
+	return r[0], nil
+}
+
+// Get returns a copy of the value of the ID, or nil when there is none.
+func (s *MemoryStore[T]) Get(_ context.Context, id string) (*T, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	i := s.indexOf(id)
+	if i < 0 {
+		return nil, nil
+	}
+	r := s.values[i]
+	return &r, nil
+}
+
+// List returns the values selected by the arguments, in the order they were
+// created unless they are sorted.
+//
+// The null values, i.e. nil pointers or invalid null wrappers, only match the
+// neq operator and the isNull operator when it is true. They come first when
+// the values are sorted in ascending order.
+func (s *MemoryStore[T]) List(_ context.Context, args FilterArgs) ([]T, error) {
+	if args.Offset != nil && *args.Offset < 0 {
+		return nil, fmt.Errorf("the offset %d is negative", *args.Offset)
+	}
+	if args.Limit != nil && *args.Limit < 0 {
+		return nil, fmt.Errorf("the limit %d is negative", *args.Limit)
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r := []T{}
+	for _, value := range s.values {
+		if args.Filter == nil || matchFilter(reflect.ValueOf(value), *args.Filter) {
+			r = append(r, value)
+		}
+	}
+	if len(args.OrderBy) > 0 {
+		sort.SliceStable(r, func(i, j int) bool {
+			a, b := reflect.ValueOf(r[i]), reflect.ValueOf(r[j])
+			for _, order := range args.OrderBy {
+				c := compareNullable(fieldValue(a, order.Index), fieldValue(b, order.Index))
+				if c != 0 {
+					return (c < 0) != order.Desc
+				}
+			}
+			return false
+		})
+	}
+	if args.Offset != nil {
+		r = r[min(*args.Offset, len(r)):]
+	}
+	if args.Limit != nil {
+		r = r[:min(*args.Limit, len(r))]
+	}
+	return r, nil
+}
+
+// Create stores the value. The key is assigned when it is a zero string or
+// integer, and it cannot be used by another value.
+func (s *MemoryStore[T]) Create(_ context.Context, value T) (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key, err := fieldByIndexAlloc(reflect.ValueOf(&value).Elem(), s.key.index)
+	if err != nil {
+		return nil, err
+	}
+	if key.IsZero() {
+		s.lastID++
+		switch key.Kind() {
+		case reflect.String:
+			key.SetString(strconv.FormatInt(s.lastID, 10))
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			key.SetInt(s.lastID)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			key.SetUint(uint64(s.lastID))
+		default:
+			return nil, fmt.Errorf("the key %s is required", s.key.name)
+		}
+	} else if n, ok := keyNumber(key); ok && n > s.lastID {
+		// The keys assigned follow the keys informed.
+		s.lastID = n
+	}
+
+	id := fmt.Sprint(key.Interface())
+	if s.indexOf(id) >= 0 {
+		return nil, fmt.Errorf("the key '%s' already exists", id)
+	}
+	s.values = append(s.values, value)
+	return &value, nil
+}
+
+// Update applies the patch to a copy of the value of the ID, and stores it
+// when there is no error. The key cannot be changed.
+func (s *MemoryStore[T]) Update(_ context.Context, id string, patch Patch[T]) (*T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexOf(id)
+	if i < 0 {
+		return nil, nil
+	}
+	value := s.values[i]
+	err := patch.Apply(&value)
+	if err != nil {
+		return nil, err
+	}
+	if s.keyOf(value) != id {
+		return nil, fmt.Errorf("the key '%s' cannot be changed", id)
+	}
+	s.values[i] = value
+	return &value, nil
+}
+
+// Delete removes the value of the ID, and reports whether it existed.
+func (s *MemoryStore[T]) Delete(_ context.Context, id string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.indexOf(id)
+	if i < 0 {
+		return false, nil
+	}
+	s.values = append(s.values[:i], s.values[i+1:]...)
+	return true, nil
+}
+
+// Values returns a copy of the values, in the order they were created.
+func (s *MemoryStore[T]) Values() []T {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]T{}, s.values...)
+}
+
+func (s *MemoryStore[T]) indexOf(id string) int {
+	for i := range s.values {
+		if s.keyOf(s.values[i]) == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// keyOf returns the key of the value, formatted as the IDs received by the
+// store.
+func (s *MemoryStore[T]) keyOf(value T) string {
+	v, ok := fieldByIndex(reflect.ValueOf(value), s.key.index)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// keyNumber returns the number of the integer keys, or of the string keys
+// that hold integers.
+func keyNumber(key reflect.Value) (int64, bool) {
+	if key.Kind() == reflect.String {
+		n, err := strconv.ParseInt(key.String(), 10, 64)
+		return n, err == nil
+	}
+	return toInt64(key)
+}
+
+// matchFilter checks if the struct v matches the filter.
+func matchFilter(v reflect.Value, filter Filter) bool {
+	for _, c := range filter.Conditions {
+		if !matchCondition(fieldValue(v, c.Index), c) {
+			return false
+		}
+	}
+	for _, sub := range filter.And {
+		if !matchFilter(v, sub) {
+			return false
+		}
+	}
+	if len(filter.Or) > 0 {
+		matched := false
+		for _, sub := range filter.Or {
+			if matchFilter(v, sub) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return filter.Not == nil || !matchFilter(v, *filter.Not)
+}
+
+// matchCondition checks if the value of a field matches the condition. v is
+// invalid when the value is null.
+func matchCondition(v reflect.Value, c Condition) bool {
+	switch c.Operator {
+	case FilterIsNull:
+		isNull, _ := c.Value.(bool)
+		return isNull == !v.IsValid()
+	case FilterNeq:
+		return !v.IsValid() || compareValues(v, reflect.ValueOf(c.Value)) != 0
+	}
+	if !v.IsValid() {
+		return false
+	}
+	switch c.Operator {
+	case FilterEq:
+		return compareValues(v, reflect.ValueOf(c.Value)) == 0
+	case FilterIn:
+		values := reflect.ValueOf(c.Value)
+		if values.Kind() != reflect.Slice {
+			return false
+		}
+		for i := 0; i < values.Len(); i++ {
+			if compareValues(v, values.Index(i)) == 0 {
+				return true
+			}
+		}
+		return false
+	case FilterLt:
+		return compareValues(v, reflect.ValueOf(c.Value)) < 0
+	case FilterGt:
+		return compareValues(v, reflect.ValueOf(c.Value)) > 0
+	case FilterContains:
+		s, _ := c.Value.(string)
+		return strings.Contains(fmt.Sprint(v.Interface()), s)
+	}
+	return false
+}
+
+// fieldValue returns the value of the field of the struct v with the index
+// informed, following the pointers and unwrapping the null wrappers. It is
+// invalid when the value is null.
+func fieldValue(v reflect.Value, index []int) reflect.Value {
+	v, ok := fieldByIndex(v, index)
+	if !ok {
+		return reflect.Value{}
+	}
+	for {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			v = v.Elem()
+			continue
+		}
+		if field, ok := nullWrapperValue(v.Type()); ok {
+			if !v.FieldByName("Valid").Bool() {
+				return reflect.Value{}
+			}
+			v = v.FieldByIndex(field.Index)
+			continue
+		}
+		return v
+	}
+}
+
+// compareNullable compares the values of two fields, the null values come
+// first.
+func compareNullable(a reflect.Value, b reflect.Value) int {
+	switch {
+	case !a.IsValid() && !b.IsValid():
+		return 0
+	case !a.IsValid():
+		return -1
+	case !b.IsValid():
+		return 1
+	}
+	return compareValues(a, b)
+}
+
+// compareValues returns -1, 0 or 1 when a is less than, equal to or greater
+// than b. The times, the dates and the big integers are compared by their
+// values, and the values of other types are compared by their text.
+func compareValues(a reflect.Value, b reflect.Value) int {
+	if a.Type() == b.Type() {
+		switch x := a.Interface().(type) {
+		case time.Time:
+			return x.Compare(b.Interface().(time.Time))
+		case Date:
+			return x.In(time.UTC).Compare(b.Interface().(Date).In(time.UTC))
+		case big.Int:
+			y := b.Interface().(big.Int)
+			return x.Cmp(&y)
+		}
+	}
+	if x, ok := toInt64(a); ok {
+		if y, ok := toInt64(b); ok {
+			return compareOrdered(x, y)
+		}
+	}
+	if x, ok := toUint64(a); ok {
+		if y, ok := toUint64(b); ok {
+			return compareOrdered(x, y)
+		}
+	}
+	if x, ok := toFloat64(a); ok {
+		if y, ok := toFloat64(b); ok {
+			return compareOrdered(x, y)
+		}
+	}
+	if a.Kind() == reflect.Bool && b.Kind() == reflect.Bool {
+		return compareOrdered(strconv.FormatBool(a.Bool()), strconv.FormatBool(b.Bool()))
+	}
+	if a.Kind() == reflect.String && b.Kind() == reflect.String {
+		return compareOrdered(a.String(), b.String())
+	}
+	return compareOrdered(fmt.Sprint(a.Interface()), fmt.Sprint(b.Interface()))
+}
+
+// toFloat64 converts numeric values to float64.
+func toFloat64(v reflect.Value) (float64, bool) {
+	switch v.Kind() {
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	if n, ok := toInt64(v); ok {
+		return float64(n), true
+	}
+	if n, ok := toUint64(v); ok {
+		return float64(n), true
+	}
+	return 0, false
+}
+
+func compareOrdered[V int64 | uint64 | float64 | string](a V, b V) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,216 @@
+package gql_auto_test
+
+import (
+	"context"
+	"database/sql"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+)
+
+type MemoryBook struct {
+	ID        int            `graphql:"!id,key"`
+	Title     string         `graphql:"!title"`
+	Pages     int            `graphql:"pages"`
+	Rating    *float64       `graphql:"rating"`
+	Status    FilterStatus   `graphql:"status"`
+	Subtitle  sql.NullString `graphql:"subtitle"`
+	Published time.Time      `graphql:"published"`
+}
+
+type MemoryNoKey struct {
+	Title string `graphql:"title"`
+}
+
+type MemoryTwoKeys struct {
+	ID    string `graphql:"id,key"`
+	Email string `graphql:"email,key"`
+}
+
+func float64Ptr(f float64) *float64 {
+	return &f
+}
+
+func TestMemoryStore_CRUD(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	store, err := gql_auto.NewMemoryStore(
+		MemoryBook{ID: 10, Title: "Dune", Pages: 412, Rating: float64Ptr(4.5), Status: "ACTIVE",
+			Published: time.Date(1965, time.August, 1, 0, 0, 0, 0, time.UTC)},
+		MemoryBook{Title: "Neuromancer", Pages: 271, Status: "INACTIVE",
+			Subtitle: sql.NullString{String: "Sprawl", Valid: true}, Published: time.Date(1984, time.July, 1, 0, 0, 0, 0, time.UTC)},
+		MemoryBook{Title: "Hyperion", Pages: 482, Rating: float64Ptr(4.2), Status: "ACTIVE",
+			Published: time.Date(1989, time.May, 26, 0, 0, 0, 0, time.UTC)},
+	)
+	ass.NoError(err)
+
+	enc := gql_auto.NewEncoder()
+	schema, err := enc.SchemaBuilder().
+		CRUD(gql_auto.CRUD[MemoryBook](store)).
+		Build()
+	ass.NoError(err)
+	ass.Equal("Int!", schema.Type("MemoryBook").(*graphql.Object).Fields()["id"].Type.String())
+	// The key is optional in the input, so it is assigned by the store.
+	ass.Equal("Int", schema.Type("MemoryBookInput").(*graphql.InputObject).Fields()["id"].Type.String())
+
+	do := func(request string) interface{} {
+		res := graphql.Do(graphql.Params{Schema: schema, RequestString: request})
+		ass.Empty(res.Errors, request)
+		return res.Data
+	}
+	titles := func(request string) []string {
+		data, _ := do(request).(map[string]interface{})
+		books, _ := data["memoryBooks"].([]interface{})
+		r := []string{}
+		for _, book := range books {
+			r = append(r, book.(map[string]interface{})["title"].(string))
+		}
+		return r
+	}
+
+	ass.Equal([]string{"Dune", "Neuromancer", "Hyperion"}, titles(`{ memoryBooks { title } }`))
+	ass.Equal([]string{"Neuromancer", "Hyperion"}, titles(`{ memoryBooks(filter: {title: {contains: "er"}}) { title } }`))
+	ass.Equal([]string{"Dune", "Hyperion"}, titles(`{ memoryBooks(filter: {pages: {gt: 300}}) { title } }`))
+	ass.Equal([]string{"Dune", "Hyperion"}, titles(`{ memoryBooks(filter: {status: {eq: ACTIVE}}) { title } }`))
+	ass.Equal([]string{"Dune", "Neuromancer"}, titles(`{ memoryBooks(filter: {id: {in: [10, 11]}}) { title } }`))
+	ass.Equal([]string{"Neuromancer"}, titles(`{ memoryBooks(filter: {rating: {isNull: true}}) { title } }`))
+	ass.Equal([]string{"Dune", "Hyperion"}, titles(`{ memoryBooks(filter: {subtitle: {isNull: true}}) { title } }`))
+	ass.Equal([]string{"Neuromancer", "Hyperion"}, titles(`{ memoryBooks(filter: {rating: {neq: 4.5}}) { title } }`))
+	ass.Equal([]string{"Dune"}, titles(`{ memoryBooks(filter: {published: {lt: "1980-01-01T00:00:00Z"}}) { title } }`))
+	ass.Equal([]string{"Dune", "Neuromancer"}, titles(`{ memoryBooks(filter: {or: [{pages: {lt: 300}}, {rating: {gt: 4.4}}]}) { title } }`))
+	ass.Equal([]string{"Neuromancer"}, titles(`{ memoryBooks(filter: {and: [{pages: {lt: 450}}], not: {status: {eq: ACTIVE}}}) { title } }`))
+
+	ass.Equal([]string{"Hyperion", "Dune", "Neuromancer"}, titles(`{ memoryBooks(orderBy: [PAGES_DESC]) { title } }`))
+	ass.Equal([]string{"Neuromancer", "Hyperion", "Dune"}, titles(`{ memoryBooks(orderBy: [RATING_ASC]) { title } }`))
+	ass.Equal([]string{"Hyperion", "Dune", "Neuromancer"}, titles(`{ memoryBooks(orderBy: [STATUS_ASC, TITLE_DESC]) { title } }`))
+	ass.Equal([]string{"Hyperion"}, titles(`{ memoryBooks(orderBy: [TITLE_ASC], offset: 1, limit: 1) { title } }`))
+	ass.Equal([]string{}, titles(`{ memoryBooks(offset: 5) { title } }`))
+
+	// The keys assigned follow the keys informed.
+	ass.Equal(map[string]interface{}{
+		"createMemoryBook": map[string]interface{}{"id": 13, "title": "Solaris"},
+	}, do(`mutation { createMemoryBook(input: {title: "Solaris"}) { id title } }`))
+	ass.Equal(map[string]interface{}{
+		"updateMemoryBook": map[string]interface{}{"id": 13, "title": "Solaris", "pages": 204},
+	}, do(`mutation { updateMemoryBook(id: 13, input: {pages: 204}) { id title pages } }`))
+	ass.Equal(map[string]interface{}{
+		"memoryBook": map[string]interface{}{"pages": 204},
+	}, do(`{ memoryBook(id: "13") { pages } }`))
+	ass.Equal(map[string]interface{}{
+		"deleteMemoryBook": true,
+	}, do(`mutation { deleteMemoryBook(id: 13) }`))
+	ass.Len(store.Values(), 3)
+
+	res := graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation { createMemoryBook(input: {id: 10, title: "Dune"}) { id } }`})
+	ass.Len(res.Errors, 1)
+	ass.Contains(res.Errors[0].Message, "already exists")
+	res = graphql.Do(graphql.Params{Schema: schema, RequestString: `mutation { updateMemoryBook(id: 10, input: {id: 20}) { id } }`})
+	ass.Len(res.Errors, 1)
+	ass.Contains(res.Errors[0].Message, "cannot be changed")
+	ass.Equal(10, store.Values()[0].ID)
+
+	_, err = gql_auto.NewMemoryStore[MemoryNoKey]()
+	ass.ErrorContains(err, "must have one key field, it has 0")
+	_, err = gql_auto.NewMemoryStore[MemoryTwoKeys]()
+	ass.ErrorContains(err, "must have one key field, it has 2")
+}
+
+func TestMemoryStore_Concurrency(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	store, err := gql_auto.NewMemoryStore[MemoryNote]()
+	ass.NoError(err)
+	enc := gql_auto.NewEncoder()
+
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := store.Create(ctx, MemoryNote{Name: "x"})
+			ass.NoError(err)
+			patch, err := gql_auto.DecodePatch[MemoryNote](enc, map[string]interface{}{"name": "y"})
+			ass.NoError(err)
+			_, err = store.Update(ctx, value.ID, patch)
+			ass.NoError(err)
+			_, err = store.List(ctx, gql_auto.FilterArgs{})
+			ass.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	values := store.Values()
+	ass.Len(values, 20)
+	ids := map[string]bool{}
+	for _, value := range values {
+		ass.Equal("y", value.Name)
+		ids[value.ID] = true
+	}
+	ass.Len(ids, 20)
+}
+
+func TestMemoryStore_ListNegativePagination(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	store, err := gql_auto.NewMemoryStore(MemoryNote{Name: "x"})
+	ass.NoError(err)
+
+	negative := -1
+	_, err = store.List(context.Background(), gql_auto.FilterArgs{Offset: &negative})
+	ass.ErrorContains(err, "the offset -1 is negative")
+	_, err = store.List(context.Background(), gql_auto.FilterArgs{Limit: &negative})
+	ass.ErrorContains(err, "the limit -1 is negative")
+}
+
+type MemoryNote struct {
+	ID   string `graphql:"id,key"`
+	Name string `graphql:"name"`
+}
+
+type MemoryScore struct {
+	ID     string        `graphql:"id,key"`
+	Points big.Int       `graphql:"points"`
+	Day    gql_auto.Date `graphql:"day"`
+}
+
+func TestMemoryStore_ListOrderedScalars(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	store, err := gql_auto.NewMemoryStore(
+		MemoryScore{ID: "a", Points: *big.NewInt(9), Day: gql_auto.Date{Year: 2024, Month: time.December, Day: 9}},
+		MemoryScore{ID: "b", Points: *big.NewInt(10), Day: gql_auto.Date{Year: 2024, Month: time.December, Day: 10}},
+	)
+	ass.NoError(err)
+
+	enc := gql_auto.NewEncoder(gql_auto.WithBuiltinScalars())
+	list := func(args map[string]interface{}) []string {
+		filterArgs, err := enc.DecodeFilterArgs(graphql.ResolveParams{Args: args}, reflect.TypeOf(MemoryScore{}))
+		ass.NoError(err)
+		values, err := store.List(context.Background(), filterArgs)
+		ass.NoError(err)
+		ids := []string{}
+		for _, value := range values {
+			ids = append(ids, value.ID)
+		}
+		return ids
+	}
+
+	// The text of 10 is less than the text of 9.
+	ass.Equal([]string{"b"}, list(map[string]interface{}{
+		"filter": map[string]interface{}{"points": map[string]interface{}{"gt": "9"}},
+	}))
+	ass.Equal([]string{"b", "a"}, list(map[string]interface{}{"orderBy": []interface{}{"POINTS_DESC"}}))
+	ass.Equal([]string{"a"}, list(map[string]interface{}{
+		"filter": map[string]interface{}{"day": map[string]interface{}{"lt": "2024-12-10"}},
+	}))
+}
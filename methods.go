@@ -0,0 +1,197 @@
+package gql_auto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/graphql-go/graphql"
+)
+
+// resolveMethodPrefix is the prefix of the methods that are turned into
+// fields of the objects, i.e. ResolveFullName -> fullName.
+const resolveMethodPrefix = "Resolve"
+
+// GraphqlFields is the interface implemented by structs that expose methods
+// without the "Resolve" prefix as fields of their objects.
+//
+// ```
+//
+//	func (Person) GraphqlFields() map[string]string {
+//	    return map[string]string{"FullName": "name", "Age": ""}
+//	}
+//
+// ```
+type GraphqlFields interface {
+	// GraphqlFields returns the names of the fields by the names of the
+	// methods. When the name is empty, it is obtained from the method.
+	GraphqlFields() map[string]string
+}
+
+var (
+	graphqlFieldsType = reflect.TypeOf(new(GraphqlFields)).Elem()
+	contextType       = reflect.TypeOf(new(context.Context)).Elem()
+	errorType         = reflect.TypeOf(new(error)).Elem()
+)
+
+// methodFields returns the fields of the struct t backed by its methods.
+//
+// The methods named "Resolve<Name>", and the methods listed by the
+// `GraphqlFields` interface, become fields. They can receive a
+// `context.Context` and a struct with the arguments of the field, and they
+// return the value of the field, optionally followed by an error:
+//
+// ```
+//
+//	func (p *Person) ResolveFriends(ctx context.Context, args FriendsArgs) ([]*Person, error)
+//
+// ```
+//
+// The methods are called on the source of the resolver.
+func (enc *Encoder) methodFields(t reflect.Type) (map[string]*graphql.Field, error) {
+	// The method set of the pointer has the methods with both value and
+	// pointer receivers.
+	pt := reflect.PtrTo(t)
+
+	names := map[string]string{}
+	if pt.Implements(graphqlFieldsType) {
+		fields, err := callback(enc, t, "GraphqlFields", reflect.New(t).Interface().(GraphqlFields).GraphqlFields)
+		if err != nil {
+			return nil, err
+		}
+		for method, name := range fields {
+			if _, ok := pt.MethodByName(method); !ok {
+				return nil, fmt.Errorf("%s has no method %s", t, method)
+			}
+			if name == "" {
+				name = toLowerCamelCase(method)
+			}
+			names[method] = name
+		}
+	}
+	for i := 0; i < pt.NumMethod(); i++ {
+		method := pt.Method(i)
+		if _, ok := names[method.Name]; ok || !isResolveMethod(method.Name) {
+			continue
+		}
+		names[method.Name] = toLowerCamelCase(strings.TrimPrefix(method.Name, resolveMethodPrefix))
+	}
+
+	r := map[string]*graphql.Field{}
+	for _, method := range sortedKeys(names) {
+		m, _ := pt.MethodByName(method)
+		field, err := enc.methodField(t, m)
+		if err != nil {
+			return nil, fmt.Errorf("method %s of %s: %w", method, t, err)
+		}
+		field.Name = names[method]
+		r[field.Name] = field
+	}
+	return r, nil
+}
+
+// isResolveMethod checks if the name of the method follows the "Resolve<Name>"
+// convention.
+func isResolveMethod(name string) bool {
+	if !strings.HasPrefix(name, resolveMethodPrefix) {
+		return false
+	}
+	next, _ := utf8.DecodeRuneInString(name[len(resolveMethodPrefix):])
+	return unicode.IsUpper(next)
+}
+
+// methodField returns the field backed by the method m of the struct t.
+func (enc *Encoder) methodField(t reflect.Type, m reflect.Method) (*graphql.Field, error) {
+	mt := m.Type
+	if mt.IsVariadic() {
+		return nil, fmt.Errorf("unsupported signature %s", mt)
+	}
+
+	// The first input is the receiver.
+	in := 1
+	hasContext := mt.NumIn() > in && mt.In(in) == contextType
+	if hasContext {
+		in++
+	}
+	var argsType reflect.Type
+	if mt.NumIn() > in {
+		argsType = mt.In(in)
+		in++
+	}
+	if mt.NumIn() != in {
+		return nil, fmt.Errorf("unsupported signature %s", mt)
+	}
+
+	hasError := mt.NumOut() == 2 && mt.Out(1) == errorType
+	if (mt.NumOut() != 1 && !hasError) || mt.Out(0) == errorType {
+		return nil, fmt.Errorf("unsupported signature %s", mt)
+	}
+
+	r := &graphql.Field{}
+	if argsType != nil {
+		args, err := enc.argsOf(argsType)
+		if err != nil {
+			return nil, err
+		}
+		r.Args = args
+	}
+
+	outType := mt.Out(0)
+	fieldType, err := enc.buildFieldType(outType)
+	if err != nil {
+		return nil, err
+	}
+	enc.registerType(outType, fieldType)
+	if enc.inferNonNull(outType) {
+		fieldType = nonNullOf(fieldType)
+	}
+	r.Type = fieldType
+
+	r.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		recv, err := methodReceiver(t, p.Source)
+		if err != nil {
+			return nil, err
+		}
+		values := []reflect.Value{recv}
+		if hasContext {
+			ctx := p.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			values = append(values, reflect.ValueOf(ctx))
+		}
+		if argsType != nil {
+			args := reflect.New(argsType)
+			err := enc.Decode(p.Args, args.Interface())
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, args.Elem())
+		}
+
+		out := m.Func.Call(values)
+		if hasError && !out[1].IsNil() {
+			return nil, out[1].Interface().(error)
+		}
+		return out[0].Interface(), nil
+	}
+	r.Resolve = enc.mapEntriesResolve(outType, enc.mapStrategy, r.Resolve)
+	r.Resolve = enc.sqlValueResolve(outType, r.Resolve)
+	return r, nil
+}
+
+// methodReceiver returns a pointer to the source of a resolver, so the
+// methods with both value and pointer receivers can be called on it.
+func methodReceiver(t reflect.Type, source interface{}) (reflect.Value, error) {
+	v := reflect.ValueOf(source)
+	if v.Kind() == reflect.Ptr && v.Type().Elem() == t && !v.IsNil() {
+		return v, nil
+	}
+	if v.IsValid() && v.Type() == t {
+		return addressableCopy(v), nil
+	}
+	return reflect.Value{}, fmt.Errorf("cannot call the methods of %s on %T", t, source)
+}
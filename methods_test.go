@@ -0,0 +1,137 @@
+package gql_auto_test
+
+import (
+	"context"
+	"errors"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+type MethodPerson struct {
+	FirstName string `graphql:"firstName"`
+	LastName  string `graphql:"lastName"`
+	Friends   []*MethodPerson
+}
+
+type MethodGreetingArgs struct {
+	Greeting string `graphql:"!greeting"`
+}
+
+func (p MethodPerson) ResolveFullName() string {
+	return p.FirstName + " " + p.LastName
+}
+
+func (p *MethodPerson) ResolveGreet(ctx context.Context, args MethodGreetingArgs) (string, error) {
+	if args.Greeting == "" {
+		return "", errors.New("empty greeting")
+	}
+	return args.Greeting + ", " + p.FirstName + ctx.Value(methodSuffixKey{}).(string), nil
+}
+
+func (p *MethodPerson) ResolveBestFriend(ctx context.Context) (*MethodPerson, error) {
+	if len(p.Friends) == 0 {
+		return nil, nil
+	}
+	return p.Friends[0], nil
+}
+
+func (p MethodPerson) Initials() string {
+	return p.FirstName[:1] + p.LastName[:1]
+}
+
+func (MethodPerson) GraphqlFields() map[string]string {
+	return map[string]string{"Initials": ""}
+}
+
+type methodSuffixKey struct{}
+
+type MethodConflict struct {
+	FullName string
+}
+
+func (MethodConflict) ResolveFullName() string {
+	return ""
+}
+
+type MethodInvalid struct{}
+
+func (MethodInvalid) ResolveCount(a, b int) int {
+	return a + b
+}
+
+func TestEncoder_StructOfMethods(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	obj, err := enc.Struct(MethodPerson{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal("String", fields["fullName"].Type.String())
+	ass.Equal("String", fields["greet"].Type.String())
+	ass.Equal("greeting", fields["greet"].Args[0].Name())
+	ass.Equal("String!", fields["greet"].Args[0].Type.String())
+	ass.Equal("MethodPerson", fields["bestFriend"].Type.String())
+	ass.Equal("String", fields["initials"].Type.String())
+	ass.NotContains(fields, "graphqlFields")
+
+	schema, err := enc.SchemaBuilder().
+		Query("person", nil, MethodPerson{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return MethodPerson{
+				FirstName: "Grace",
+				LastName:  "Hopper",
+				Friends:   []*MethodPerson{{FirstName: "Ada", LastName: "Lovelace"}},
+			}, nil
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema:  schema,
+		Context: context.WithValue(context.Background(), methodSuffixKey{}, "!"),
+		RequestString: `{
+			person {
+				fullName
+				initials
+				greet(greeting: "Hello")
+				bestFriend { fullName greet(greeting: "Hi") }
+			}
+		}`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"person": map[string]interface{}{
+			"fullName": "Grace Hopper",
+			"initials": "GH",
+			"greet":    "Hello, Grace!",
+			"bestFriend": map[string]interface{}{
+				"fullName": "Ada Lovelace",
+				"greet":    "Hi, Ada!",
+			},
+		},
+	}, res.Data)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		Context:       context.Background(),
+		RequestString: `{ person { greet(greeting: "") } }`,
+	})
+	ass.Len(res.Errors, 1)
+	ass.Equal("empty greeting", res.Errors[0].Message)
+}
+
+func TestEncoder_StructOfInvalidMethods(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	_, err := enc.Struct(MethodConflict{})
+	ass.ErrorContains(err, "field fullName of gql_auto_test.MethodConflict is defined by both a struct field and a method")
+
+	_, err = enc.Struct(MethodInvalid{})
+	ass.Error(err)
+	ass.True(strings.HasPrefix(err.Error(), "method ResolveCount of gql_auto_test.MethodInvalid: unsupported signature"))
+}
@@ -0,0 +1,60 @@
+package gql_auto
+
+import (
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// NamingStrategy returns the GraphQL name of the objects, input objects and
+// other named types generated from a Go type.
+type NamingStrategy func(t reflect.Type) string
+
+// TypeNameNaming names the GraphQL types after the name of the Go type,
+// i.e. models.User -> User. This is the default strategy.
+func TypeNameNaming(t reflect.Type) string {
+	return t.Name()
+}
+
+// PackagePrefixNaming names the GraphQL types after the name of the Go type
+// prefixed by the name of its package, i.e. models.User -> ModelsUser.
+func PackagePrefixNaming(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if i := strings.LastIndex(pkg, "/"); i >= 0 {
+		pkg = pkg[i+1:]
+	}
+	prefix := []rune{}
+	upper := true
+	for _, r := range pkg {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		prefix = append(prefix, r)
+	}
+	return string(prefix) + t.Name()
+}
+
+// cacheKey returns the type used as key on the caches of the encoder. The
+// pointers share the types of the values they point to.
+func cacheKey(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Ptr {
+		return t.Elem()
+	}
+	return t
+}
+
+// claimName reserves the GraphQL name for the Go type t. It fails when the
+// name was already taken by another Go type.
+func (enc *Encoder) claimName(name string, t reflect.Type) error {
+	t = cacheKey(t)
+	if owner, ok := enc.names[name]; ok && owner != t {
+		return NewErrNameCollision(name, owner, t)
+	}
+	setCache(enc, enc.names, name, t)
+	return nil
+}
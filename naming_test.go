@@ -0,0 +1,89 @@
+package gql_auto_test
+
+import (
+	"errors"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+func namingFirstUser() reflect.Type {
+	type User struct {
+		Name string `graphql:"name"`
+	}
+	return reflect.TypeOf(User{})
+}
+
+func namingSecondUser() reflect.Type {
+	type User struct {
+		Email string `graphql:"email"`
+	}
+	return reflect.TypeOf(User{})
+}
+
+func TestEncoder_NameCollision(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	first, err := enc.StructOf(namingFirstUser())
+	ass.NoError(err)
+	ass.Equal("User", first.Name())
+
+	// the same type is taken from the cache
+	again, err := enc.StructOf(reflect.PtrTo(namingFirstUser()))
+	ass.NoError(err)
+	ass.Same(first, again)
+
+	_, err = enc.StructOf(namingSecondUser())
+	ass.Error(err)
+	ass.ErrorContains(err, "graphql name 'User'")
+
+	var collision *gql_auto.NameCollisionError
+	ass.True(errors.As(err, &collision))
+
+	_, err = enc.InputObjectOf(namingFirstUser())
+	ass.NoError(err)
+	_, err = enc.InputObjectOf(namingSecondUser())
+	ass.Error(err)
+	ass.ErrorContains(err, "graphql name 'UserInput'")
+}
+
+func TestEncoder_WithNamingStrategy(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	names := map[reflect.Type]string{
+		namingFirstUser():  "FirstUser",
+		namingSecondUser(): "SecondUser",
+	}
+	enc := gql_auto.NewEncoder(gql_auto.WithNamingStrategy(func(t reflect.Type) string {
+		return names[t]
+	}))
+
+	first, err := enc.StructOf(namingFirstUser())
+	ass.NoError(err)
+	ass.Equal("FirstUser", first.Name())
+	second, err := enc.StructOf(namingSecondUser())
+	ass.NoError(err)
+	ass.Equal("SecondUser", second.Name())
+	ass.Contains(second.Fields(), "email")
+
+	input, err := enc.InputObjectOf(namingSecondUser())
+	ass.NoError(err)
+	ass.Equal("SecondUserInput", input.Name())
+}
+
+func TestPackagePrefixNaming(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	ass.Equal("GqlAutoTestUser", gql_auto.PackagePrefixNaming(namingFirstUser()))
+	ass.Equal("UuidUUID", gql_auto.PackagePrefixNaming(reflect.TypeOf(UUIDStruct{}.UID)))
+
+	obj, err := gql_auto.NewEncoder(gql_auto.WithNamingStrategy(gql_auto.PackagePrefixNaming)).
+		StructOf(namingFirstUser())
+	ass.NoError(err)
+	ass.Equal("GqlAutoTestUser", obj.Name())
+}
@@ -0,0 +1,63 @@
+package gql_auto
+
+import (
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// Nullability defines which fields are NonNull in the schema.
+type Nullability int
+
+const (
+	// NullableByDefault makes every field nullable, unless its tag starts
+	// with "!". This is the default.
+	NullableByDefault Nullability = iota
+	// InferFromPointers makes the fields of value types NonNull, i.e. string,
+	// int or structs, while pointers, slices, maps and interfaces stay
+	// nullable. The elements of lists are NonNull unless they are pointers:
+	//
+	// ```
+	//
+	//	type T struct {
+	//	    Name     string    // String!
+	//	    Nickname *string   // String
+	//	    Tags     []string  // [String!]
+	//	    Friends  []*Person // [Person]
+	//	}
+	//
+	// ```
+	//
+	// The tag overrides the inference, a leading "!" makes the field NonNull
+	// and the "nullable" option makes it nullable. The arguments and input
+	// fields with a default value stay nullable, so they can be omitted.
+	InferFromPointers
+)
+
+// WithNullability sets the strategy that decides which fields are NonNull.
+func WithNullability(nullability Nullability) EncoderOption {
+	return func(enc *Encoder) {
+		enc.nullability = nullability
+	}
+}
+
+// inferNonNull checks if a value of the Go type t is NonNull by the
+// nullability of the encoder.
+func (enc *Encoder) inferNonNull(t reflect.Type) bool {
+	if enc.nullability != InferFromPointers {
+		return false
+	}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return false
+	}
+	return !enc.isNullWrapper(t) && !enc.isDriverValuer(t)
+}
+
+// nonNullOf wraps t with NonNull, unless it is already NonNull.
+func nonNullOf(t graphql.Type) graphql.Type {
+	if _, ok := t.(*graphql.NonNull); ok {
+		return t
+	}
+	return graphql.NewNonNull(t)
+}
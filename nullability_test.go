@@ -0,0 +1,98 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type NullabilityAudit struct {
+	CreatedBy string
+}
+
+type NullabilityPerson struct {
+	*NullabilityAudit
+	Name     string
+	Nickname *string
+	Age      int     `graphql:"age,nullable"`
+	Email    *string `graphql:"!email"`
+	Tags     []string
+	Scores   [2]int
+	Friends  []*NullabilityPerson
+	Best     NullabilityFriend
+	Labels   map[string]string
+}
+
+type NullabilityFriend struct {
+	Name string
+}
+
+type NullabilityArgs struct {
+	Name  string
+	Limit int `graphql:"limit,default=10"`
+	Page  *int
+	Tags  []string
+}
+
+func TestEncoder_InferFromPointers(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder(gql_auto.WithNullability(gql_auto.InferFromPointers))
+	obj, err := enc.Struct(NullabilityPerson{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal("String", fields["createdBy"].Type.String())
+	ass.Equal("String!", fields["name"].Type.String())
+	ass.Equal("String", fields["nickname"].Type.String())
+	ass.Equal("Int", fields["age"].Type.String())
+	ass.Equal("String!", fields["email"].Type.String())
+	ass.Equal("[String!]", fields["tags"].Type.String())
+	ass.Equal("[Int!]!", fields["scores"].Type.String())
+	ass.Equal("[NullabilityPerson]", fields["friends"].Type.String())
+	ass.Equal("NullabilityFriend!", fields["best"].Type.String())
+	ass.Equal("JSON", fields["labels"].Type.String())
+
+	args, err := enc.ArgsOf(reflect.TypeOf(NullabilityArgs{}))
+	ass.NoError(err)
+	ass.Equal("String!", args["name"].Type.String())
+	ass.Equal("Int", args["limit"].Type.String())
+	ass.Equal("Int", args["page"].Type.String())
+	ass.Equal("[String!]", args["tags"].Type.String())
+
+	schema, err := enc.SchemaBuilder().
+		Query("person", NullabilityArgs{}, NullabilityPerson{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return NullabilityPerson{Name: "duke", Tags: []string{"a"}}, nil
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ person(name: "duke") { createdBy name tags } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"person": map[string]interface{}{
+			"createdBy": nil,
+			"name":      "duke",
+			"tags":      []interface{}{"a"},
+		},
+	}, res.Data)
+}
+
+func TestEncoder_NullableByDefault(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	obj, err := enc.Struct(NullabilityPerson{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal("String", fields["name"].Type.String())
+	ass.Equal("String!", fields["email"].Type.String())
+	ass.Equal("[String]", fields["tags"].Type.String())
+	ass.Equal("NullabilityFriend", fields["best"].Type.String())
+}
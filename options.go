@@ -0,0 +1,18 @@
+package gql_auto
+
+// EncoderOption configures an `Encoder` created by `NewEncoder`.
+type EncoderOption func(enc *Encoder)
+
+// WithNamingStrategy sets the strategy used to name the GraphQL types
+// generated from Go types.
+//
+// ```
+//
+//	enc := gql_auto.NewEncoder(gql_auto.WithNamingStrategy(gql_auto.PackagePrefixNaming))
+//
+// ```
+func WithNamingStrategy(naming NamingStrategy) EncoderOption {
+	return func(enc *Encoder) {
+		enc.naming = naming
+	}
+}
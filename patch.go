@@ -0,0 +1,286 @@
+package gql_auto
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// patchInputTypeSuffix is appended to the name of a struct to name the input
+// object that updates it, i.e. Person -> PersonPatchInput.
+const patchInputTypeSuffix = "PatchInput"
+
+// Patch is a partial update of a value of the struct T, decoded by
+// `DecodePatch` from the input object of `PatchInputOf`. It keeps which
+// fields were informed, so the fields omitted are distinguished from the
+// fields set to null:
+//
+// ```
+//
+//	patch, err := gql_auto.DecodePatchArg[Person](enc, p, "input")
+//	if patch.Has("email") {
+//	    // The email was informed, it is the zero value when it is null.
+//	    sendConfirmation(patch.Value.Email)
+//	}
+//	err = patch.Apply(&person)
+//
+// ```
+//
+// The fields are the fields of the input object, so the nested structs are
+// replaced as a whole.
+type Patch[T any] struct {
+	// Value holds the values of the fields informed, the other fields are
+	// zero.
+	Value  T
+	fields []decodedField
+	set    fieldSet
+	null   fieldSet
+}
+
+// fieldSet is a bitmap of the positions of the fields of a `Patch`.
+type fieldSet []uint64
+
+func (s *fieldSet) add(i int) {
+	for len(*s) <= i/64 {
+		*s = append(*s, 0)
+	}
+	(*s)[i/64] |= 1 << (i % 64)
+}
+
+func (s fieldSet) has(i int) bool {
+	return i/64 < len(s) && s[i/64]&(1<<(i%64)) != 0
+}
+
+// Has checks if the field with the GraphQL name informed was set, even to
+// null.
+func (p Patch[T]) Has(name string) bool {
+	i := p.fieldIndex(name)
+	return i >= 0 && p.set.has(i)
+}
+
+// IsNull checks if the field with the GraphQL name informed was set to null.
+func (p Patch[T]) IsNull(name string) bool {
+	i := p.fieldIndex(name)
+	return i >= 0 && p.null.has(i)
+}
+
+// Fields returns the GraphQL names of the fields set, in the order of the
+// struct.
+func (p Patch[T]) Fields() []string {
+	r := []string{}
+	for i, sf := range p.fields {
+		if p.set.has(i) {
+			r = append(r, sf.name)
+		}
+	}
+	return r
+}
+
+// Apply copies the fields set from Value into dst. The fields set to null
+// become zero.
+func (p Patch[T]) Apply(dst *T) error {
+	src := reflect.ValueOf(&p.Value).Elem()
+	v := reflect.ValueOf(dst).Elem()
+	for i, sf := range p.fields {
+		if !p.set.has(i) {
+			continue
+		}
+		field, err := fieldByIndexAlloc(v, sf.index)
+		if err != nil {
+			return NewErrDecode(sf.name, err)
+		}
+		value, ok := fieldByIndex(src, sf.index)
+		if !ok {
+			field.Set(reflect.Zero(field.Type()))
+			continue
+		}
+		field.Set(value)
+	}
+	return nil
+}
+
+func (p Patch[T]) fieldIndex(name string) int {
+	for i, sf := range p.fields {
+		if sf.name == name {
+			return i
+		}
+	}
+	return -1
+}
+
+// DecodePatch decodes src, the value of an input object built by
+// `PatchInputOf`, into a `Patch`.
+func DecodePatch[T any](enc *Encoder, src interface{}) (Patch[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return Patch[T]{}, fmt.Errorf("cannot decode a patch of %s, it is not a struct", t)
+	}
+	m, ok := src.(map[string]interface{})
+	if !ok {
+		return Patch[T]{}, fmt.Errorf("cannot decode %T into a patch", src)
+	}
+
+	r := Patch[T]{fields: enc.decodedFieldsOf(t)}
+	v := reflect.ValueOf(&r.Value).Elem()
+	for i, sf := range r.fields {
+		value, ok := m[sf.name]
+		if !ok {
+			continue
+		}
+		r.set.add(i)
+		if value == nil {
+			r.null.add(i)
+			continue
+		}
+		err := enc.decodeField(v, sf.name, sf, value)
+		if err != nil {
+			return Patch[T]{}, err
+		}
+	}
+	return r, nil
+}
+
+// variablesKey is the key of the raw variables of a request in its context.
+type variablesKey struct{}
+
+// ContextWithVariables returns a copy of ctx holding the variables of the
+// request as they were received, so `DecodePatchArg` finds the fields of the
+// variables set to null. graphql-go removes them from the arguments, and
+// there is no null literal in its queries:
+//
+// ```
+//
+//	res := graphql.Do(graphql.Params{
+//	    Schema:         schema,
+//	    RequestString:  req.Query,
+//	    VariableValues: req.Variables,
+//	    Context:        gql_auto.ContextWithVariables(ctx, req.Variables),
+//	})
+//
+// ```
+func ContextWithVariables(ctx context.Context, variables map[string]interface{}) context.Context {
+	return context.WithValue(ctx, variablesKey{}, variables)
+}
+
+// DecodePatchArg decodes the argument name of the resolver, an input object
+// built by `PatchInputOf`, into a `Patch`. The fields set to null by the
+// variables are found in the variables of the context set by
+// `ContextWithVariables`, otherwise they are taken as omitted.
+func DecodePatchArg[T any](enc *Encoder, p graphql.ResolveParams, name string) (Patch[T], error) {
+	src, ok := p.Args[name].(map[string]interface{})
+	if !ok {
+		return DecodePatch[T](enc, p.Args[name])
+	}
+	var variables map[string]interface{}
+	if p.Context != nil {
+		variables, _ = p.Context.Value(variablesKey{}).(map[string]interface{})
+	}
+	if nulls := nullInputFields(p.Info.FieldASTs, name, variables); len(nulls) > 0 {
+		m := make(map[string]interface{}, len(src)+len(nulls))
+		for k, v := range src {
+			m[k] = v
+		}
+		for _, field := range nulls {
+			m[field] = nil
+		}
+		src = m
+	}
+	return DecodePatch[T](enc, src)
+}
+
+// nullInputFields returns the fields of the input object of the argument
+// name set to null, either by a variable holding the input object or by
+// variables of its fields.
+func nullInputFields(fields []*ast.Field, name string, variables map[string]interface{}) []string {
+	if len(fields) == 0 || variables == nil {
+		return nil
+	}
+	// isNull checks if the value is a variable informed as null.
+	isNull := func(value ast.Value) bool {
+		v, ok := value.(*ast.Variable)
+		if !ok || v.Name == nil {
+			return false
+		}
+		raw, ok := variables[v.Name.Value]
+		return ok && raw == nil
+	}
+
+	var r []string
+	for _, arg := range fields[0].Arguments {
+		if arg.Name == nil || arg.Name.Value != name {
+			continue
+		}
+		switch value := arg.Value.(type) {
+		case *ast.Variable:
+			if value.Name == nil {
+				break
+			}
+			raw, _ := variables[value.Name.Value].(map[string]interface{})
+			for field, v := range raw {
+				if v == nil {
+					r = append(r, field)
+				}
+			}
+		case *ast.ObjectValue:
+			for _, field := range value.Fields {
+				if field.Name != nil && isNull(field.Value) {
+					r = append(r, field.Name.Value)
+				}
+			}
+		}
+	}
+	return r
+}
+
+// PatchInputOf returns the input object that updates the struct t, named
+// "<Name>PatchInput". Its fields are the fields of the input object of t,
+// but they are all optional and have no default values, so the fields
+// omitted are not changed. Its values are decoded by `DecodePatch`.
+func (enc *Encoder) PatchInputOf(t reflect.Type) (*graphql.InputObject, error) {
+	return build(enc, func() (*graphql.InputObject, error) {
+		return enc.patchInputOf(t)
+	})
+}
+
+func (enc *Encoder) patchInputOf(t reflect.Type) (*graphql.InputObject, error) {
+	t = cacheKey(t)
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot build a patch input object from a non struct")
+	}
+	name := enc.naming(t) + patchInputTypeSuffix
+	err := enc.claimName(name, t)
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := enc.entries[name]; ok {
+		return r.(*graphql.InputObject), nil
+	}
+
+	inputFields := graphql.InputObjectConfigFieldMap{}
+	err = enc.inputFields(t, inputFields)
+	if err != nil {
+		return nil, err
+	}
+	fields := graphql.InputObjectConfigFieldMap{}
+	for fieldName, field := range inputFields {
+		fieldType := field.Type
+		if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nonNull.OfType
+		}
+		fields[fieldName] = &graphql.InputObjectFieldConfig{
+			Type:        fieldType,
+			Description: field.Description,
+		}
+	}
+	r := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        name,
+		Description: fmt.Sprintf("The fields of a %s to update.", enc.naming(t)),
+		Fields:      fields,
+	})
+	freeze(r)
+	setCache(enc, enc.entries, name, graphql.Type(r))
+	return r, nil
+}
@@ -0,0 +1,169 @@
+package gql_auto_test
+
+import (
+	"context"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type PatchAudit struct {
+	Editor string `graphql:"editor"`
+}
+
+type PatchAddress struct {
+	City string `graphql:"!city"`
+	Zip  string `graphql:"zip"`
+}
+
+type PatchProfile struct {
+	*PatchAudit
+	Name     string       `graphql:"!name"`
+	Age      int          `graphql:"age,default=18"`
+	Nickname *string      `graphql:"nickname"`
+	Tags     []string     `graphql:"tags"`
+	Address  PatchAddress `graphql:"address"`
+}
+
+func TestEncoder_PatchInputOf(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder(gql_auto.WithNullability(gql_auto.InferFromPointers))
+	input, err := enc.PatchInputOf(reflect.TypeOf(PatchProfile{}))
+	ass.NoError(err)
+	ass.Equal("PatchProfilePatchInput", input.Name())
+	fields := input.Fields()
+	ass.Equal("String", fields["name"].Type.String())
+	ass.Equal("Int", fields["age"].Type.String())
+	ass.Nil(fields["age"].DefaultValue)
+	ass.Equal("String", fields["nickname"].Type.String())
+	ass.Equal("[String!]", fields["tags"].Type.String())
+	ass.Equal("PatchAddressInput", fields["address"].Type.String())
+	ass.Equal("String", fields["editor"].Type.String())
+
+	// The nested input objects keep their NonNull fields.
+	ass.Equal("String!", fields["address"].Type.(*graphql.InputObject).Fields()["city"].Type.String())
+
+	cached, err := enc.PatchInputOf(reflect.TypeOf(&PatchProfile{}))
+	ass.NoError(err)
+	ass.Same(input, cached)
+
+	// The patch inputs are listed by the types of the encoder.
+	ass.Contains(enc.Types(), graphql.Type(input))
+	ass.Contains(enc.PrintSDL(), "input PatchProfilePatchInput {")
+
+	_, err = enc.PatchInputOf(reflect.TypeOf(""))
+	ass.Error(err)
+}
+
+func TestDecodePatch(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	input, err := enc.PatchInputOf(reflect.TypeOf(PatchProfile{}))
+	ass.NoError(err)
+
+	var patch gql_auto.Patch[PatchProfile]
+	schema, err := enc.SchemaBuilder().
+		MutationField("patch", graphql.Field{
+			Type: graphql.Boolean,
+			Args: graphql.FieldConfigArgument{
+				"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(input)},
+			},
+			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				var err error
+				patch, err = gql_auto.DecodePatchArg[PatchProfile](enc, p, "input")
+				return err == nil, err
+			},
+		}).
+		QueryField("ok", graphql.Field{Type: graphql.Boolean}).
+		Build()
+	ass.NoError(err)
+
+	nickname := "Ada"
+	profile := PatchProfile{
+		Name:     "Ada Lovelace",
+		Age:      36,
+		Nickname: &nickname,
+		Tags:     []string{"math"},
+		Address:  PatchAddress{City: "London", Zip: "W1"},
+	}
+
+	// The variables can set fields to null.
+	variables := map[string]interface{}{
+		"input": map[string]interface{}{
+			"name":     "Augusta Ada King",
+			"nickname": nil,
+			"address":  map[string]interface{}{"city": "Marylebone"},
+			"editor":   "Babbage",
+		},
+	}
+	res := graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `mutation ($input: PatchProfilePatchInput!) { patch(input: $input) }`,
+		VariableValues: variables,
+		Context:        gql_auto.ContextWithVariables(context.Background(), variables),
+	})
+	ass.Empty(res.Errors)
+	ass.Equal([]string{"editor", "name", "nickname", "address"}, patch.Fields())
+	ass.True(patch.Has("name"))
+	ass.False(patch.IsNull("name"))
+	ass.True(patch.Has("nickname"))
+	ass.True(patch.IsNull("nickname"))
+	ass.False(patch.Has("age"))
+	ass.False(patch.Has("unknown"))
+	ass.Equal("Augusta Ada King", patch.Value.Name)
+
+	ass.NoError(patch.Apply(&profile))
+	ass.Equal(PatchProfile{
+		PatchAudit: &PatchAudit{Editor: "Babbage"},
+		Name:       "Augusta Ada King",
+		Age:        36,
+		Tags:       []string{"math"},
+		// The nested structs are replaced as a whole.
+		Address: PatchAddress{City: "Marylebone"},
+	}, profile)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { patch(input: {age: 37, tags: ["logic"]}) }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal([]string{"age", "tags"}, patch.Fields())
+	ass.NoError(patch.Apply(&profile))
+	ass.Equal(37, profile.Age)
+	ass.Equal([]string{"logic"}, profile.Tags)
+	ass.Equal("Augusta Ada King", profile.Name)
+
+	variables = map[string]interface{}{"nickname": nil, "age": 38}
+	res = graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `mutation ($nickname: String, $age: Int, $tags: [String]) { patch(input: {nickname: $nickname, age: $age, tags: $tags}) }`,
+		VariableValues: variables,
+		Context:        gql_auto.ContextWithVariables(context.Background(), variables),
+	})
+	ass.Empty(res.Errors)
+	ass.Equal([]string{"age", "nickname"}, patch.Fields())
+	ass.True(patch.IsNull("nickname"))
+
+	// Without the variables of the context, the fields set to null are
+	// omitted.
+	res = graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `mutation ($nickname: String, $age: Int) { patch(input: {nickname: $nickname, age: $age}) }`,
+		VariableValues: variables,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal([]string{"age"}, patch.Fields())
+
+	_, err = gql_auto.DecodePatch[PatchProfile](enc, map[string]interface{}{"age": "old"})
+	ass.ErrorContains(err, "age")
+	_, err = gql_auto.DecodePatch[PatchProfile](enc, "name")
+	ass.Error(err)
+	_, err = gql_auto.DecodePatch[string](enc, map[string]interface{}{})
+	ass.Error(err)
+}
@@ -6,12 +6,38 @@ import (
 )
 
 // GraphqlResolver is the interface implemented by types that will provide a resolver.
+//
+// The method is called on a zero value of the type, not on the value of the
+// field, so it cannot use the data of its receiver. `GraphqlValueResolver`
+// is called on the value of the field instead.
 type GraphqlResolver interface {
 	// GraphqlResolve is the method will be set to the `graphql.Field` as the
 	// resolver method.
 	GraphqlResolve(p graphql.ResolveParams) (interface{}, error)
 }
 
+// GraphqlValueResolver is the interface implemented by types that resolve
+// themselves. The method is called on the value of the field read from the
+// source, so types like lazy references can resolve from their own data:
+//
+// ```
+//
+//	type UserRef struct {
+//	    ID string
+//	}
+//
+//	func (r UserRef) GraphqlResolveValue(p graphql.ResolveParams) (interface{}, error) {
+//	    return findUser(p.Context, r.ID)
+//	}
+//
+// ```
+//
+// A nil pointer resolves to null without calling the method. It takes
+// precedence over `GraphqlResolver`.
+type GraphqlValueResolver interface {
+	GraphqlResolveValue(p graphql.ResolveParams) (interface{}, error)
+}
+
 func fieldResolve(field reflect.StructField) graphql.FieldResolveFn {
 	t := field.Type
 
@@ -32,3 +58,126 @@ func fieldResolve(field reflect.StructField) graphql.FieldResolveFn {
 
 	return nil
 }
+
+// isValueResolver checks if the type t, or a pointer to it, implements the
+// `GraphqlValueResolver` interface.
+func isValueResolver(t reflect.Type) bool {
+	t = cacheKey(t)
+	return t.Implements(graphqlValueResolverType) || reflect.PtrTo(t).Implements(graphqlValueResolverType)
+}
+
+// valueResolve returns the resolver of a field whose type implements the
+// `GraphqlValueResolver` interface.
+func valueResolve(r fieldReader) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v, ok, err := r.read(p)
+		if !ok {
+			return nil, err
+		}
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return nil, nil
+			}
+			if resolver, ok := v.Interface().(GraphqlValueResolver); ok {
+				return resolver.GraphqlResolveValue(p)
+			}
+			v = v.Elem()
+		}
+		if resolver, ok := v.Interface().(GraphqlValueResolver); ok {
+			return resolver.GraphqlResolveValue(p)
+		}
+		// The method has a pointer receiver, so it is called on a copy.
+		return addressableCopy(v).Interface().(GraphqlValueResolver).GraphqlResolveValue(p)
+	}
+}
+
+// fieldReader reads the value of a struct field from the source of a
+// resolver. The index of the field is computed once, when the object is
+// built, so the field is read by the exact Go struct field, whatever its
+// GraphQL name is.
+type fieldReader struct {
+	// typ is the struct the object was built from.
+	typ   reflect.Type
+	index []int
+	// name is the GraphQL name of the field, and goName the name of the
+	// struct field.
+	name   string
+	goName string
+}
+
+func newFieldReader(t reflect.Type, field structField) fieldReader {
+	return fieldReader{
+		typ:    t,
+		index:  field.index,
+		name:   field.name,
+		goName: field.Name,
+	}
+}
+
+// read returns the value of the field from the source of the resolver. The
+// pointers, including the pointers to embedded structs, are followed. Maps
+// are read by the GraphQL name of the field, or by its Go name, and other
+// sources by the default resolver of graphql-go. ok is false when the value
+// cannot be reached.
+func (r fieldReader) read(p graphql.ResolveParams) (v reflect.Value, ok bool, err error) {
+	source := reflect.ValueOf(p.Source)
+	for source.Kind() == reflect.Ptr || source.Kind() == reflect.Interface {
+		if source.IsNil() {
+			return reflect.Value{}, false, nil
+		}
+		source = source.Elem()
+	}
+
+	switch {
+	case !source.IsValid():
+		return reflect.Value{}, false, nil
+	case source.Type() == r.typ:
+		v, ok = fieldByIndex(source, r.index)
+		return v, ok, nil
+	case source.Kind() == reflect.Map && source.Type().Key().Kind() == reflect.String:
+		for _, name := range []string{r.name, r.goName} {
+			v = source.MapIndex(reflect.ValueOf(name).Convert(source.Type().Key()))
+			if v.IsValid() {
+				return v, true, nil
+			}
+		}
+		return reflect.Value{}, false, nil
+	}
+
+	value, err := graphql.DefaultResolveFn(p)
+	if err != nil || value == nil {
+		return reflect.Value{}, false, err
+	}
+	return reflect.ValueOf(value), true, nil
+}
+
+// fieldByIndex returns the field of the struct v with the index informed,
+// following the pointers to embedded structs. ok is false when one of them
+// is nil.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, bool) {
+	for _, i := range index {
+		for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+			if v.IsNil() {
+				return reflect.Value{}, false
+			}
+			v = v.Elem()
+		}
+		if v.Kind() != reflect.Struct || i >= v.NumField() {
+			return reflect.Value{}, false
+		}
+		v = v.Field(i)
+	}
+	return v, true
+}
+
+// structFieldResolve returns the default resolver of the fields of the
+// objects built by the encoder.
+func structFieldResolve(r fieldReader) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v, ok, err := r.read(p)
+		if !ok {
+			return nil, err
+		}
+		return v.Interface(), nil
+	}
+}
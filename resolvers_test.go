@@ -0,0 +1,79 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type ResolverAccount struct {
+	UID       string `graphql:"id"`
+	FullName  string `json:"name"`
+	Email     string `graphql:"!contact"`
+	Suspended bool
+}
+
+// ResolverAccountView has the same GraphQL names of `ResolverAccount`, but
+// different Go fields.
+type ResolverAccountView struct {
+	Id   string
+	Name string
+}
+
+func TestEncoder_StructOfFieldResolvers(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	var source interface{}
+	schema, err := enc.SchemaBuilder().
+		Query("account", nil, ResolverAccount{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return source, nil
+		}).
+		Build()
+	ass.NoError(err)
+
+	query := func(selection string) *graphql.Result {
+		return graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ account { ` + selection + ` } }`,
+		})
+	}
+
+	source = &ResolverAccount{UID: "1", FullName: "Duke", Email: "duke@example.com", Suspended: true}
+	res := query("id name contact suspended")
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"account": map[string]interface{}{
+			"id":        "1",
+			"name":      "Duke",
+			"contact":   "duke@example.com",
+			"suspended": true,
+		},
+	}, res.Data)
+
+	// Maps are read by the GraphQL name, or by the Go name.
+	source = map[string]interface{}{"id": "2", "FullName": "Ada", "contact": "ada@example.com"}
+	res = query("id name contact suspended")
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"account": map[string]interface{}{
+			"id":        "2",
+			"name":      "Ada",
+			"contact":   "ada@example.com",
+			"suspended": nil,
+		},
+	}, res.Data)
+
+	// Other structs are read by the default resolver of graphql-go.
+	source = ResolverAccountView{Id: "3", Name: "Grace"}
+	res = query("id name")
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"account": map[string]interface{}{
+			"id":   "3",
+			"name": "Grace",
+		},
+	}, res.Data)
+}
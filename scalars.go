@@ -0,0 +1,484 @@
+package gql_auto
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"net/mail"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// ScalarFuncs are the functions that convert the values of a custom scalar.
+type ScalarFuncs struct {
+	// Serialize converts a Go value to its representation in the response.
+	Serialize func(value interface{}) (interface{}, error)
+	// Parse converts a value of the request, i.e. a string, a number or a
+	// boolean, to the Go type of the scalar.
+	Parse func(value interface{}) (interface{}, error)
+}
+
+// RegisterScalar registers the Go type t as a custom GraphQL scalar.
+//
+// When no functions are informed, the scalar is represented by strings,
+// and its values are converted by the `encoding.TextMarshaler` and
+// `encoding.TextUnmarshaler` methods of t. The fields of type t, in both
+// objects and arguments, become fields of the scalar.
+//
+// ```
+//
+//	_, err := enc.RegisterScalar(reflect.TypeOf(Money{}), "Money", "An amount of money, i.e. 10.50 EUR")
+//
+// ```
+func (enc *Encoder) RegisterScalar(t reflect.Type, name string, description string, funcs ...ScalarFuncs) (*graphql.Scalar, error) {
+	return build(enc, func() (*graphql.Scalar, error) {
+		return enc.registerScalar(t, name, description, funcs)
+	})
+}
+
+func (enc *Encoder) registerScalar(t reflect.Type, name string, description string, funcs []ScalarFuncs) (*graphql.Scalar, error) {
+	t = cacheKey(t)
+	if r, ok := enc.getType(t); ok {
+		return nil, fmt.Errorf("%s was already built as %s", t, r)
+	}
+
+	var f ScalarFuncs
+	switch len(funcs) {
+	case 0:
+		var err error
+		f, err = textScalarFuncs(t)
+		if err != nil {
+			return nil, err
+		}
+	case 1:
+		f = funcs[0]
+	default:
+		return nil, fmt.Errorf("scalar %s has more than one set of functions", name)
+	}
+
+	err := enc.claimName(name, t)
+	if err != nil {
+		return nil, err
+	}
+	r := NewScalar(name, description, f)
+	enc.addScalar(t, r)
+	return r, nil
+}
+
+// addScalar represents the Go type t by the scalar informed, in both objects
+// and arguments.
+func (enc *Encoder) addScalar(t reflect.Type, scalar *graphql.Scalar) {
+	setCache(enc, enc.names, scalar.Name(), t)
+	enc.registerType(t, scalar)
+	enc.registerInputType(t, scalar)
+	// The null wrappers and the driver.Valuer structs registered as
+	// scalars are decoded as the other scalars.
+	enc.decodedTypes.Range(func(key, _ interface{}) bool {
+		enc.decodedTypes.Delete(key)
+		return true
+	})
+}
+
+// NewScalar returns a custom GraphQL scalar that converts its values by the
+// functions informed. The values that cannot be converted are rejected.
+func NewScalar(name string, description string, funcs ScalarFuncs) *graphql.Scalar {
+	return graphql.NewScalar(scalarConfig(name, description, funcs))
+}
+
+// scalarConfig returns the configuration of the scalars built by
+// `NewScalar`.
+func scalarConfig(name string, description string, funcs ScalarFuncs) graphql.ScalarConfig {
+	return graphql.ScalarConfig{
+		Name:        name,
+		Description: description,
+		Serialize: func(value interface{}) interface{} {
+			value, ok := derefValue(value)
+			if !ok {
+				return nil
+			}
+			r, err := funcs.Serialize(value)
+			if err != nil {
+				return nil
+			}
+			return r
+		},
+		ParseValue: func(value interface{}) interface{} {
+			r, err := funcs.Parse(value)
+			if err != nil {
+				return nil
+			}
+			return r
+		},
+		ParseLiteral: func(valueAST ast.Value) interface{} {
+			switch valueAST.(type) {
+			case *ast.StringValue, *ast.IntValue, *ast.FloatValue, *ast.BooleanValue:
+				r, err := funcs.Parse(parseJSONLiteral(valueAST))
+				if err != nil {
+					return nil
+				}
+				return r
+			}
+			return nil
+		},
+	}
+}
+
+// derefValue follows the pointers of a value. ok is false when the value is
+// nil.
+func derefValue(value interface{}) (interface{}, bool) {
+	v := reflect.ValueOf(value)
+	for v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil, false
+		}
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, false
+	}
+	return v.Interface(), true
+}
+
+// textScalarFuncs returns the functions of a scalar represented by the text
+// of the values of t.
+func textScalarFuncs(t reflect.Type) (ScalarFuncs, error) {
+	pt := reflect.PtrTo(t)
+	if !pt.Implements(textMarshalerType) || !pt.Implements(textUnmarshalerType) {
+		return ScalarFuncs{}, fmt.Errorf("%s does not implement encoding.TextMarshaler and encoding.TextUnmarshaler", t)
+	}
+
+	return ScalarFuncs{
+		Serialize: func(value interface{}) (interface{}, error) {
+			v := reflect.ValueOf(value)
+			if v.Type() != t {
+				return nil, fmt.Errorf("%T is not a %s", value, t)
+			}
+			b, err := addressableCopy(v).Interface().(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return nil, err
+			}
+			return string(b), nil
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%T is not a string", value)
+			}
+			ptr := reflect.New(t)
+			err := ptr.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+			if err != nil {
+				return nil, err
+			}
+			return ptr.Elem().Interface(), nil
+		},
+	}, nil
+}
+
+// WithBuiltinScalars represents the Go types below by the built-in scalars:
+//
+// * uuid.UUID: `UUIDScalar`, instead of `graphql.String`;
+// * url.URL: `URLScalar`;
+// * big.Int: `BigIntScalar`.
+//
+// The types `time.Duration`, `Date`, `Decimal` and `Email` are always
+// represented by their scalars.
+func WithBuiltinScalars() EncoderOption {
+	return func(enc *Encoder) {
+		enc.addScalar(uuidType, UUIDScalar)
+		enc.addScalar(reflect.TypeOf(url.URL{}), URLScalar)
+		enc.addScalar(reflect.TypeOf(big.Int{}), BigIntScalar)
+	}
+}
+
+func mustTextScalarFuncs(t reflect.Type) ScalarFuncs {
+	r, err := textScalarFuncs(t)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
+
+// UUIDScalar represents `uuid.UUID` values by their canonical string.
+var UUIDScalar = NewScalar(
+	"UUID",
+	"The `UUID` scalar type represents an UUID as a string, i.e. \"123e4567-e89b-12d3-a456-426614174000\".",
+	mustTextScalarFuncs(uuidType),
+)
+
+// DurationScalar represents `time.Duration` values by strings in the format
+// of Go, i.e. "1h30m". The ISO-8601 format, i.e. "PT1H30M", is also accepted
+// as input.
+var DurationScalar = NewScalar(
+	"Duration",
+	"The `Duration` scalar type represents a duration as a string of decimal numbers with units, i.e. \"1h30m\". The ISO-8601 format, i.e. \"PT1H30M\", is also accepted as input.",
+	ScalarFuncs{
+		Serialize: func(value interface{}) (interface{}, error) {
+			d, ok := value.(time.Duration)
+			if !ok {
+				return nil, fmt.Errorf("%T is not a time.Duration", value)
+			}
+			return d.String(), nil
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%T is not a string", value)
+			}
+			if strings.Contains(s, "P") {
+				return parseISODuration(s)
+			}
+			return time.ParseDuration(s)
+		},
+	},
+)
+
+var isoDurationPattern = regexp.MustCompile(`^([-+])?P(?:([\d.]+)D)?(?:T(?:([\d.]+)H)?(?:([\d.]+)M)?(?:([\d.]+)S)?)?$`)
+
+// parseISODuration parses an ISO-8601 duration with days, hours, minutes and
+// seconds, i.e. "P1DT2H30M". Years and months are rejected, since their
+// length varies.
+func parseISODuration(s string) (time.Duration, error) {
+	m := isoDurationPattern.FindStringSubmatch(s)
+	if m == nil || s[len(s)-1] == 'P' || s[len(s)-1] == 'T' {
+		return 0, fmt.Errorf("'%s' is not an ISO-8601 duration", s)
+	}
+	var r time.Duration
+	for i, unit := range []string{"h", "h", "m", "s"} {
+		if m[i+2] == "" {
+			continue
+		}
+		d, err := time.ParseDuration(m[i+2] + unit)
+		if err != nil {
+			return 0, fmt.Errorf("'%s' is not an ISO-8601 duration", s)
+		}
+		if i == 0 {
+			// Days
+			d *= 24
+		}
+		r += d
+	}
+	if m[1] == "-" {
+		r = -r
+	}
+	return r, nil
+}
+
+// URLScalar represents `url.URL` values by strings.
+var URLScalar = NewScalar(
+	"URL",
+	"The `URL` scalar type represents an absolute URL as a string, i.e. \"https://example.com/path\".",
+	ScalarFuncs{
+		Serialize: func(value interface{}) (interface{}, error) {
+			u, ok := value.(url.URL)
+			if !ok {
+				return nil, fmt.Errorf("%T is not an url.URL", value)
+			}
+			return u.String(), nil
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			s, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("%T is not a string", value)
+			}
+			u, err := url.Parse(s)
+			if err != nil {
+				return nil, err
+			}
+			if !u.IsAbs() {
+				return nil, fmt.Errorf("'%s' is not an absolute URL", s)
+			}
+			return *u, nil
+		},
+	},
+)
+
+// BigIntScalar represents `big.Int` values by strings, so they are not
+// limited to the 53 bits of JSON numbers. Integers are also accepted as
+// input, including the literals beyond 64 bits and the whole numbers of the
+// JSON variables.
+var BigIntScalar = newBigIntScalar()
+
+func newBigIntScalar() *graphql.Scalar {
+	cfg := scalarConfig(
+		"BigInt",
+		"The `BigInt` scalar type represents an integer of arbitrary size as a string, i.e. \"12345678901234567890\".",
+		ScalarFuncs{
+			Serialize: func(value interface{}) (interface{}, error) {
+				n, ok := value.(big.Int)
+				if !ok {
+					return nil, fmt.Errorf("%T is not a big.Int", value)
+				}
+				return n.String(), nil
+			},
+			Parse: parseBigInt,
+		},
+	)
+	// The integer literals are parsed from their text, they can exceed the
+	// 64 bits of parseJSONLiteral.
+	cfg.ParseLiteral = func(valueAST ast.Value) interface{} {
+		var value string
+		switch valueAST := valueAST.(type) {
+		case *ast.IntValue:
+			value = valueAST.Value
+		case *ast.StringValue:
+			value = valueAST.Value
+		default:
+			return nil
+		}
+		r, err := parseBigInt(value)
+		if err != nil {
+			return nil
+		}
+		return r
+	}
+	return graphql.NewScalar(cfg)
+}
+
+// parseBigInt converts the strings, the integers and the whole numbers of the
+// JSON variables to `big.Int`.
+func parseBigInt(value interface{}) (interface{}, error) {
+	var n big.Int
+	switch value := value.(type) {
+	case string:
+		if _, ok := n.SetString(value, 10); !ok {
+			return nil, fmt.Errorf("'%s' is not an integer", value)
+		}
+	case json.Number:
+		if _, ok := n.SetString(value.String(), 10); ok {
+			break
+		}
+		f, _, err := big.ParseFloat(value.String(), 10, 0, big.ToNearestEven)
+		if err != nil || !f.IsInt() {
+			return nil, fmt.Errorf("'%s' is not an integer", value)
+		}
+		f.Int(&n)
+	case int:
+		n.SetInt64(int64(value))
+	case int64:
+		n.SetInt64(value)
+	case float64:
+		if math.IsInf(value, 0) || math.Trunc(value) != value {
+			return nil, fmt.Errorf("%v is not an integer", value)
+		}
+		big.NewFloat(value).Int(&n)
+	default:
+		return nil, fmt.Errorf("%T is not an integer", value)
+	}
+	return n, nil
+}
+
+// Date is a calendar date, without time and location, represented by the
+// `DateScalar`.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the date of the time informed, in its location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// In returns the time at the start of the date in the location informed.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+func (d Date) MarshalText() ([]byte, error) {
+	return []byte(d.String()), nil
+}
+
+func (d *Date) UnmarshalText(text []byte) error {
+	t, err := time.Parse(time.DateOnly, string(text))
+	if err != nil {
+		return err
+	}
+	*d = DateOf(t)
+	return nil
+}
+
+func (*Date) GraphqlType() graphql.Type {
+	return DateScalar
+}
+
+// DateScalar represents `Date` values by strings in the format "2006-01-02".
+var DateScalar = NewScalar(
+	"Date",
+	"The `Date` scalar type represents a calendar date as a string in the format YYYY-MM-DD.",
+	mustTextScalarFuncs(reflect.TypeOf(Date{})),
+)
+
+// Decimal is a decimal number kept as text, so it has no rounding errors,
+// represented by the `DecimalScalar`. It can be converted to the decimal
+// type of any library.
+type Decimal string
+
+var decimalPattern = regexp.MustCompile(`^[-+]?(\d+(\.\d*)?|\.\d+)$`)
+
+func (d Decimal) MarshalText() ([]byte, error) {
+	return []byte(d), nil
+}
+
+func (d *Decimal) UnmarshalText(text []byte) error {
+	if !decimalPattern.Match(text) {
+		return fmt.Errorf("'%s' is not a decimal number", text)
+	}
+	*d = Decimal(text)
+	return nil
+}
+
+func (*Decimal) GraphqlType() graphql.Type {
+	return DecimalScalar
+}
+
+// DecimalScalar represents `Decimal` values by strings, i.e. "10.50".
+var DecimalScalar = NewScalar(
+	"Decimal",
+	"The `Decimal` scalar type represents a decimal number as a string, so it has no rounding errors, i.e. \"10.50\".",
+	mustTextScalarFuncs(reflect.TypeOf(Decimal(""))),
+)
+
+// Email is an email address, represented by the `EmailScalar`. The
+// addresses are validated when they are parsed.
+type Email string
+
+func (e Email) MarshalText() ([]byte, error) {
+	return []byte(e), nil
+}
+
+func (e *Email) UnmarshalText(text []byte) error {
+	address, err := mail.ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+	if address.Address != string(text) {
+		return fmt.Errorf("'%s' is not a plain email address", text)
+	}
+	*e = Email(address.Address)
+	return nil
+}
+
+func (*Email) GraphqlType() graphql.Type {
+	return EmailScalar
+}
+
+// EmailScalar represents `Email` values by strings.
+var EmailScalar = NewScalar(
+	"Email",
+	"The `Email` scalar type represents an email address as a string, i.e. \"duke@example.com\".",
+	mustTextScalarFuncs(reflect.TypeOf(Email(""))),
+)
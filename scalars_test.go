@@ -0,0 +1,272 @@
+package gql_auto_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// ScalarMoney is represented by its text, i.e. "10.50 EUR".
+type ScalarMoney struct {
+	Cents    int64
+	Currency string
+}
+
+func (m ScalarMoney) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%d.%02d %s", m.Cents/100, m.Cents%100, m.Currency)), nil
+}
+
+func (m *ScalarMoney) UnmarshalText(text []byte) error {
+	var units, cents int64
+	_, err := fmt.Sscanf(string(text), "%d.%02d %s", &units, &cents, &m.Currency)
+	m.Cents = units*100 + cents
+	return err
+}
+
+// ScalarLevel is represented by its number.
+type ScalarLevel struct {
+	value int
+}
+
+type ScalarOrder struct {
+	Total ScalarMoney  `graphql:"total"`
+	Tip   *ScalarMoney `graphql:"tip"`
+	Level ScalarLevel  `graphql:"level"`
+}
+
+type ScalarOrderArgs struct {
+	Total ScalarMoney `graphql:"!total"`
+	Level ScalarLevel `graphql:"level"`
+}
+
+func TestEncoder_RegisterScalar(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	money, err := enc.RegisterScalar(reflect.TypeOf(ScalarMoney{}), "Money", "An amount of money")
+	ass.NoError(err)
+	_, err = enc.RegisterScalar(reflect.TypeOf(ScalarLevel{}), "Level", "", gql_auto.ScalarFuncs{
+		Serialize: func(value interface{}) (interface{}, error) {
+			return value.(ScalarLevel).value, nil
+		},
+		Parse: func(value interface{}) (interface{}, error) {
+			n, ok := value.(int)
+			if !ok {
+				return nil, fmt.Errorf("%T is not an int", value)
+			}
+			return ScalarLevel{value: n}, nil
+		},
+	})
+	ass.NoError(err)
+
+	obj, err := enc.Struct(ScalarOrder{})
+	ass.NoError(err)
+	ass.Equal(money, obj.Fields()["total"].Type)
+	ass.Equal(money, obj.Fields()["tip"].Type)
+	ass.Equal("Level", obj.Fields()["level"].Type.String())
+
+	var decoded ScalarOrderArgs
+	schema, err := enc.SchemaBuilder().
+		Query("order", ScalarOrderArgs{}, ScalarOrder{}, func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, &decoded)
+			return ScalarOrder{Total: decoded.Total, Level: decoded.Level}, err
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ order(total: "10.50 EUR", level: 3) { total tip level } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(ScalarOrderArgs{Total: ScalarMoney{Cents: 1050, Currency: "EUR"}, Level: ScalarLevel{value: 3}}, decoded)
+	ass.Equal(map[string]interface{}{
+		"order": map[string]interface{}{
+			"total": "10.50 EUR",
+			"tip":   nil,
+			"level": 3,
+		},
+	}, res.Data)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ order(total: 10) { total } }`,
+	})
+	ass.Len(res.Errors, 1)
+
+	_, err = enc.RegisterScalar(reflect.TypeOf(ScalarOrder{}), "Order", "")
+	ass.ErrorContains(err, "was already built as ScalarOrder")
+	_, err = enc.RegisterScalar(reflect.TypeOf(ScalarOrderArgs{}), "Args", "")
+	ass.ErrorContains(err, "does not implement encoding.TextMarshaler and encoding.TextUnmarshaler")
+	_, err = enc.RegisterScalar(reflect.TypeOf(time.Month(0)), "Money", "", gql_auto.ScalarFuncs{})
+	ass.ErrorContains(err, "graphql name 'Money'")
+}
+
+type ScalarBuiltins struct {
+	ID       uuid.UUID        `graphql:"id"`
+	Timeout  time.Duration    `graphql:"timeout"`
+	Homepage *url.URL         `graphql:"homepage"`
+	Balance  big.Int          `graphql:"balance"`
+	Birthday gql_auto.Date    `graphql:"birthday"`
+	Price    gql_auto.Decimal `graphql:"price"`
+	Email    gql_auto.Email   `graphql:"email"`
+}
+
+type ScalarDefaults struct {
+	ID       uuid.UUID     `graphql:"id"`
+	Birthday gql_auto.Date `graphql:"birthday"`
+}
+
+func TestEncoder_WithBuiltinScalars(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder(gql_auto.WithBuiltinScalars())
+	obj, err := enc.Struct(ScalarBuiltins{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal(gql_auto.UUIDScalar, fields["id"].Type)
+	ass.Equal(gql_auto.DurationScalar, fields["timeout"].Type)
+	ass.Equal(gql_auto.URLScalar, fields["homepage"].Type)
+	ass.Equal(gql_auto.BigIntScalar, fields["balance"].Type)
+	ass.Equal(gql_auto.DateScalar, fields["birthday"].Type)
+	ass.Equal(gql_auto.DecimalScalar, fields["price"].Type)
+	ass.Equal(gql_auto.EmailScalar, fields["email"].Type)
+
+	var decoded ScalarBuiltins
+	schema, err := enc.SchemaBuilder().
+		Query("builtins", ScalarBuiltins{}, ScalarBuiltins{}, func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, &decoded)
+			return &decoded, err
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema: schema,
+		RequestString: `{
+			builtins(
+				id: "123e4567-e89b-12d3-a456-426614174000",
+				timeout: "1h30m",
+				homepage: "https://example.com/duke",
+				balance: "12345678901234567890",
+				birthday: "1995-05-23",
+				price: "10.50",
+				email: "duke@example.com"
+			) { id timeout homepage balance birthday price email }
+		}`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(uuid.MustParse("123e4567-e89b-12d3-a456-426614174000"), decoded.ID)
+	ass.Equal(90*time.Minute, decoded.Timeout)
+	ass.Equal("example.com", decoded.Homepage.Host)
+	ass.Equal("12345678901234567890", decoded.Balance.String())
+	ass.Equal(gql_auto.Date{Year: 1995, Month: time.May, Day: 23}, decoded.Birthday)
+	ass.Equal(map[string]interface{}{
+		"builtins": map[string]interface{}{
+			"id":       "123e4567-e89b-12d3-a456-426614174000",
+			"timeout":  "1h30m0s",
+			"homepage": "https://example.com/duke",
+			"balance":  "12345678901234567890",
+			"birthday": "1995-05-23",
+			"price":    "10.50",
+			"email":    "duke@example.com",
+		},
+	}, res.Data)
+
+	for _, arg := range []string{`email: "duke"`, `price: "ten"`, `birthday: "23/05/1995"`, `homepage: "/duke"`} {
+		res = graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ builtins(` + arg + `) { id } }`,
+		})
+		ass.Len(res.Errors, 1, arg)
+	}
+
+	// Without the option, UUIDs are strings.
+	obj, err = gql_auto.NewEncoder().Struct(ScalarDefaults{})
+	ass.NoError(err)
+	ass.Equal(graphql.String, obj.Fields()["id"].Type)
+	ass.Equal(gql_auto.DateScalar, obj.Fields()["birthday"].Type)
+}
+
+func TestBigIntScalar(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	var n *big.Int
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"q": &graphql.Field{
+					Type: gql_auto.BigIntScalar,
+					Args: graphql.FieldConfigArgument{
+						"n": &graphql.ArgumentConfig{Type: gql_auto.BigIntScalar},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						value, ok := p.Args["n"].(big.Int)
+						if !ok {
+							return nil, fmt.Errorf("%T is not a big.Int", p.Args["n"])
+						}
+						n = &value
+						return value, nil
+					},
+				},
+			},
+		}),
+	})
+	ass.NoError(err)
+
+	// The literals beyond 64 bits.
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ q(n: 12345678901234567890) }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal("12345678901234567890", n.String())
+	ass.Equal(map[string]interface{}{"q": "12345678901234567890"}, res.Data)
+
+	// The numbers of the JSON variables.
+	for _, value := range []interface{}{float64(7), json.Number("7"), "7"} {
+		res = graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  `query($n: BigInt) { q(n: $n) }`,
+			VariableValues: map[string]interface{}{"n": value},
+		})
+		ass.Empty(res.Errors, value)
+		ass.Equal(map[string]interface{}{"q": "7"}, res.Data, value)
+	}
+	var variables map[string]interface{}
+	ass.NoError(json.Unmarshal([]byte(`{"n": 7}`), &variables))
+	res = graphql.Do(graphql.Params{
+		Schema:         schema,
+		RequestString:  `query($n: BigInt) { q(n: $n) }`,
+		VariableValues: variables,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{"q": "7"}, res.Data)
+
+	// The fractions are rejected.
+	for _, value := range []interface{}{7.5, json.Number("7.5"), "7.5"} {
+		res = graphql.Do(graphql.Params{
+			Schema:         schema,
+			RequestString:  `query($n: BigInt) { q(n: $n) }`,
+			VariableValues: map[string]interface{}{"n": value},
+		})
+		ass.Len(res.Errors, 1, value)
+	}
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ q(n: 7.5) }`,
+	})
+	ass.Len(res.Errors, 1)
+}
@@ -0,0 +1,171 @@
+package gql_auto
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// SchemaBuilder assembles a `graphql.Schema` from fields registered as
+// queries, mutations and subscriptions. The types of the fields are built by
+// the encoder bound to the builder.
+//
+// The errors found while registering fields are kept and returned by
+// `Build`, so the calls can be chained:
+//
+// ```
+//
+//	schema, err := enc.SchemaBuilder().
+//	    Query("hero", HeroArgs{}, Person{}, resolveHero).
+//	    Mutation("createHero", CreateHeroArgs{}, Person{}, createHero).
+//	    Build()
+//
+// ```
+type SchemaBuilder struct {
+	encoder      *Encoder
+	query        graphql.Fields
+	mutation     graphql.Fields
+	subscription graphql.Fields
+	errs         []error
+}
+
+// SchemaBuilder returns a new `SchemaBuilder` bound to the encoder.
+func (enc *Encoder) SchemaBuilder() *SchemaBuilder {
+	return &SchemaBuilder{
+		encoder:      enc,
+		query:        graphql.Fields{},
+		mutation:     graphql.Fields{},
+		subscription: graphql.Fields{},
+	}
+}
+
+// NewSchemaBuilder returns a new `SchemaBuilder` bound to the
+// `DefaultEncoder`.
+func NewSchemaBuilder() *SchemaBuilder {
+	return DefaultEncoder.SchemaBuilder()
+}
+
+// Query registers a query. args is the struct describing the arguments (nil
+// when the query has no arguments), and out is a value of the returned type.
+func (b *SchemaBuilder) Query(name string, args interface{}, out interface{}, resolve graphql.FieldResolveFn, options ...Option) *SchemaBuilder {
+	return b.add(b.query, name, args, out, resolve, options)
+}
+
+// Mutation registers a mutation. args is the struct describing the arguments
+// (nil when the mutation has no arguments), and out is a value of the
+// returned type.
+func (b *SchemaBuilder) Mutation(name string, args interface{}, out interface{}, resolve graphql.FieldResolveFn, options ...Option) *SchemaBuilder {
+	return b.add(b.mutation, name, args, out, resolve, options)
+}
+
+// Subscription registers a subscription. args is the struct describing the
+// arguments (nil when the subscription has no arguments), and out is a value
+// of the type of each event. The source of the events is set with
+// `WithSubscribe`.
+func (b *SchemaBuilder) Subscription(name string, args interface{}, out interface{}, resolve graphql.FieldResolveFn, options ...Option) *SchemaBuilder {
+	return b.add(b.subscription, name, args, out, resolve, options)
+}
+
+// QueryField registers a query from a field already built, i.e. by
+// `Resolver`.
+func (b *SchemaBuilder) QueryField(name string, field graphql.Field) *SchemaBuilder {
+	return b.set(b.query, name, field)
+}
+
+// MutationField registers a mutation from a field already built, i.e. by
+// `Resolver`.
+func (b *SchemaBuilder) MutationField(name string, field graphql.Field) *SchemaBuilder {
+	return b.set(b.mutation, name, field)
+}
+
+// SubscriptionField registers a subscription from a field already built.
+func (b *SchemaBuilder) SubscriptionField(name string, field graphql.Field) *SchemaBuilder {
+	return b.set(b.subscription, name, field)
+}
+
+func (b *SchemaBuilder) add(fields graphql.Fields, name string, args interface{}, out interface{}, resolve graphql.FieldResolveFn, options []Option) *SchemaBuilder {
+	field, err := b.field(args, out, resolve, options)
+	if err != nil {
+		b.errs = append(b.errs, fmt.Errorf("%s: %w", name, err))
+		return b
+	}
+	field.Name = name
+	return b.set(fields, name, field)
+}
+
+// set registers the field, or keeps an error if a field with the same name
+// is already registered.
+func (b *SchemaBuilder) set(fields graphql.Fields, name string, field graphql.Field) *SchemaBuilder {
+	if _, ok := fields[name]; ok {
+		b.errs = append(b.errs, fmt.Errorf("%s: the field is registered twice", name))
+		return b
+	}
+	fields[name] = &field
+	return b
+}
+
+func (b *SchemaBuilder) field(args interface{}, out interface{}, resolve graphql.FieldResolveFn, options []Option) (graphql.Field, error) {
+	r := graphql.Field{
+		Resolve: resolve,
+	}
+
+	outType := reflect.TypeOf(out)
+	if outType == nil {
+		return graphql.Field{}, errors.New("the returned type is required")
+	}
+	fieldType, err := b.encoder.TypeOf(outType)
+	if err != nil {
+		return graphql.Field{}, err
+	}
+	r.Type = fieldType
+
+	if args != nil {
+		r.Args, err = b.encoder.Args(args)
+		if err != nil {
+			return graphql.Field{}, err
+		}
+	}
+
+	for _, option := range options {
+		err = option.Apply(&r)
+		if err != nil {
+			return graphql.Field{}, err
+		}
+	}
+	return r, nil
+}
+
+// Build returns the schema with the fields registered. All the types known
+// by the encoder are added to the schema, even if they are not reachable
+// from the root types.
+func (b *SchemaBuilder) Build() (graphql.Schema, error) {
+	if len(b.errs) > 0 {
+		return graphql.Schema{}, errors.Join(b.errs...)
+	}
+	if len(b.query) == 0 {
+		return graphql.Schema{}, errors.New("at least one query is required")
+	}
+
+	cfg := graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Query",
+			Fields: b.query,
+		}),
+		Types: b.encoder.Types(),
+	}
+	if len(b.mutation) > 0 {
+		cfg.Mutation = graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Mutation",
+			Fields: b.mutation,
+		})
+	}
+	if len(b.subscription) > 0 {
+		cfg.Subscription = graphql.NewObject(graphql.ObjectConfig{
+			Name:   "Subscription",
+			Fields: b.subscription,
+		})
+	}
+	return graphql.NewSchema(cfg)
+}
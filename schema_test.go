@@ -0,0 +1,167 @@
+package gql_auto_test
+
+import (
+	"context"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type SchemaPerson struct {
+	Name    string          `graphql:"!name"`
+	Address *SchemaAddress  `graphql:"address"`
+	Friends []*SchemaPerson `graphql:"friends"`
+}
+
+type SchemaAddress struct {
+	City string `graphql:"city"`
+}
+
+type SchemaPersonArgs struct {
+	Name string `graphql:"!name"`
+}
+
+type SchemaCreatePersonArgs struct {
+	Person SchemaPerson `graphql:"!person"`
+}
+
+type SchemaUnused struct {
+	Value string `graphql:"value"`
+}
+
+func TestSchemaBuilder_Build(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	_, err := enc.Struct(SchemaUnused{})
+	ass.NoError(err)
+
+	count := gql_auto.Resolver(func(ctx context.Context, args struct{}) (int, error) {
+		return 1, nil
+	})
+
+	schema, err := enc.SchemaBuilder().
+		Query("person", SchemaPersonArgs{}, SchemaPerson{}, func(p graphql.ResolveParams) (interface{}, error) {
+			var args SchemaPersonArgs
+			err := enc.DecodeArgs(p, &args)
+			return &SchemaPerson{Name: args.Name}, err
+		}).
+		Query("persons", nil, []SchemaPerson{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return []SchemaPerson{{Name: "Duke"}}, nil
+		}).
+		QueryField("count", count).
+		Mutation("createPerson", SchemaCreatePersonArgs{}, SchemaPerson{}, func(p graphql.ResolveParams) (interface{}, error) {
+			var args SchemaCreatePersonArgs
+			err := enc.DecodeArgs(p, &args)
+			return args.Person, err
+		}, gql_auto.WithDescription("Creates a person")).
+		Subscription("personAdded", nil, SchemaPerson{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return p.Source, nil
+		}, gql_auto.WithSubscribe(func(p graphql.ResolveParams) (interface{}, error) {
+			c := make(chan interface{})
+			close(c)
+			return c, nil
+		})).
+		Build()
+	ass.NoError(err)
+
+	ass.NotNil(schema.QueryType())
+	ass.NotNil(schema.MutationType())
+	ass.NotNil(schema.SubscriptionType())
+	ass.NotNil(schema.Type("SchemaPerson"))
+	ass.NotNil(schema.Type("SchemaPersonInput"))
+	ass.NotNil(schema.Type("SchemaAddressInput"))
+	ass.NotNil(schema.Type("SchemaUnused"))
+	ass.Equal("Creates a person", schema.MutationType().Fields()["createPerson"].Description)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `mutation { createPerson(person: {name: "Duke", address: {city: "Berlin"}}) { name address { city } } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"createPerson": map[string]interface{}{
+			"name":    "Duke",
+			"address": map[string]interface{}{"city": "Berlin"},
+		},
+	}, res.Data)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ person(name: "Scarlett") { name } persons { name } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"person":  map[string]interface{}{"name": "Scarlett"},
+		"persons": []interface{}{map[string]interface{}{"name": "Duke"}},
+	}, res.Data)
+}
+
+func TestSchemaBuilder_BuildErrors(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	_, err := gql_auto.NewEncoder().SchemaBuilder().Build()
+	ass.Error(err)
+	ass.ErrorContains(err, "at least one query")
+
+	type Args struct {
+		Field1 []interface{} `graphql:"field1"`
+	}
+	_, err = gql_auto.NewEncoder().SchemaBuilder().
+		Query("broken", Args{}, SchemaAddress{}, nil).
+		Build()
+	ass.Error(err)
+	ass.ErrorContains(err, "broken")
+	ass.ErrorContains(err, "not recognized")
+
+	_, err = gql_auto.NewEncoder().SchemaBuilder().
+		Query("address", nil, SchemaAddress{}, nil).
+		QueryField("address", graphql.Field{Type: graphql.String}).
+		Mutation("save", nil, SchemaAddress{}, nil).
+		Mutation("save", nil, SchemaPerson{}, nil).
+		Build()
+	ass.ErrorContains(err, "address: the field is registered twice")
+	ass.ErrorContains(err, "save: the field is registered twice")
+}
+
+// SchemaEmpty has no exported fields, so it has no fields in GraphQL.
+type SchemaEmpty struct {
+	name string
+}
+
+type SchemaBroken struct {
+	Name    string         `graphql:"name"`
+	Address *SchemaAddress `graphql:"address"`
+	Value   complex128     `graphql:"value"`
+}
+
+func TestSchemaBuilder_BuildAfterError(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	for i := 0; i < 2; i++ {
+		_, err := enc.Struct(SchemaBroken{})
+		ass.ErrorContains(err, "SchemaBroken.Value")
+		_, err = enc.InputObjectOf(reflect.TypeOf(SchemaBroken{}))
+		ass.ErrorContains(err, "SchemaBroken.Value")
+		_, err = enc.Struct(SchemaEmpty{name: "empty"})
+		ass.ErrorContains(err, "SchemaEmpty has no fields")
+	}
+
+	// The types left half-built are not added to the schema.
+	schema, err := enc.SchemaBuilder().
+		Query("address", nil, SchemaAddress{}, nil).
+		Build()
+	ass.NoError(err)
+	ass.Nil(schema.Type("SchemaBroken"))
+	ass.Nil(schema.Type("SchemaBrokenInput"))
+	for _, typ := range enc.Types() {
+		ass.NotContains(typ.Name(), "SchemaBroken")
+		ass.NotEqual("SchemaEmpty", typ.Name())
+	}
+}
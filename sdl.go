@@ -0,0 +1,346 @@
+package gql_auto
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// specifiedScalars are the scalars defined by the GraphQL specification,
+// they are never printed.
+var specifiedScalars = map[string]bool{
+	"String":  true,
+	"Int":     true,
+	"Float":   true,
+	"Boolean": true,
+	"ID":      true,
+}
+
+// PrintSDL returns the GraphQL SDL of all the types built by the encoder,
+// and the types referenced by them.
+//
+// The types, fields, arguments and enum values are sorted by name, so the
+// output is deterministic and can be committed as a golden file. The types
+// are read by `Types`, so no interface can be registered for the structs
+// printed afterwards.
+func (enc *Encoder) PrintSDL() string {
+	return printTypes(enc.Types())
+}
+
+// PrintSchemaSDL returns the GraphQL SDL of the schema informed.
+//
+// The schema definition is only printed when the root types do not use the
+// default names (Query, Mutation and Subscription).
+func PrintSchemaSDL(schema graphql.Schema) string {
+	roots := []graphql.Type{}
+	var definition []string
+	custom := false
+	for _, root := range []struct {
+		operation string
+		name      string
+		object    *graphql.Object
+	}{
+		{"query", "Query", schema.QueryType()},
+		{"mutation", "Mutation", schema.MutationType()},
+		{"subscription", "Subscription", schema.SubscriptionType()},
+	} {
+		if root.object == nil {
+			continue
+		}
+		roots = append(roots, root.object)
+		definition = append(definition, fmt.Sprintf("  %s: %s", root.operation, root.object.Name()))
+		custom = custom || root.object.Name() != root.name
+	}
+
+	for _, t := range schema.TypeMap() {
+		roots = append(roots, t)
+	}
+
+	buf := &bytes.Buffer{}
+	if custom {
+		buf.WriteString("schema {\n")
+		buf.WriteString(strings.Join(definition, "\n"))
+		buf.WriteString("\n}\n\n")
+	}
+	buf.WriteString(printTypes(roots))
+	return buf.String()
+}
+
+// printTypes prints the types informed and all the types reachable from them.
+func printTypes(roots []graphql.Type) string {
+	types := map[string]graphql.Type{}
+	for _, t := range roots {
+		collectTypes(t, types)
+	}
+
+	names := make([]string, 0, len(types))
+	for name := range types {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	blocks := make([]string, 0, len(names))
+	for _, name := range names {
+		blocks = append(blocks, printType(types[name]))
+	}
+	if len(blocks) == 0 {
+		return ""
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// collectTypes adds the named type t, and the types referenced by its fields,
+// to types. The specified scalars and the introspection types are ignored.
+func collectTypes(t graphql.Type, types map[string]graphql.Type) {
+	named, ok := graphql.GetNamed(t).(graphql.Type)
+	if !ok || named == nil {
+		return
+	}
+	name := named.Name()
+	if specifiedScalars[name] || strings.HasPrefix(name, "__") {
+		return
+	}
+	if _, ok := types[name]; ok {
+		return
+	}
+	types[name] = named
+
+	switch t := named.(type) {
+	case *graphql.Object:
+		for _, iface := range t.Interfaces() {
+			collectTypes(iface, types)
+		}
+		collectFieldTypes(t.Fields(), types)
+	case *graphql.Interface:
+		collectFieldTypes(t.Fields(), types)
+	case *graphql.Union:
+		for _, member := range t.Types() {
+			collectTypes(member, types)
+		}
+	case *graphql.InputObject:
+		for _, field := range t.Fields() {
+			collectTypes(field.Type, types)
+		}
+	}
+}
+
+func collectFieldTypes(fields graphql.FieldDefinitionMap, types map[string]graphql.Type) {
+	for _, field := range fields {
+		collectTypes(field.Type, types)
+		for _, arg := range field.Args {
+			collectTypes(arg.Type, types)
+		}
+	}
+}
+
+func printType(t graphql.Type) string {
+	buf := &bytes.Buffer{}
+	printDescription(buf, "", t.Description())
+
+	switch t := t.(type) {
+	case *graphql.Scalar:
+		fmt.Fprintf(buf, "scalar %s", t.Name())
+	case *graphql.Enum:
+		fmt.Fprintf(buf, "enum %s {\n", t.Name())
+		values := append([]*graphql.EnumValueDefinition{}, t.Values()...)
+		sort.Slice(values, func(i, j int) bool {
+			return values[i].Name < values[j].Name
+		})
+		for _, value := range values {
+			printDescription(buf, "  ", value.Description)
+			fmt.Fprintf(buf, "  %s%s\n", value.Name, printDeprecated(value.DeprecationReason))
+		}
+		buf.WriteString("}")
+	case *graphql.InputObject:
+		fmt.Fprintf(buf, "input %s {\n", t.Name())
+		fields := t.Fields()
+		for _, name := range sortedKeys(fields) {
+			field := fields[name]
+			printDescription(buf, "  ", field.Description())
+			fmt.Fprintf(buf, "  %s: %s%s\n", name, field.Type, printDefaultValue(field.DefaultValue, field.Type))
+		}
+		buf.WriteString("}")
+	case *graphql.Object:
+		fmt.Fprintf(buf, "type %s", t.Name())
+		if len(t.Interfaces()) > 0 {
+			names := make([]string, 0, len(t.Interfaces()))
+			for _, iface := range t.Interfaces() {
+				names = append(names, iface.Name())
+			}
+			sort.Strings(names)
+			fmt.Fprintf(buf, " implements %s", strings.Join(names, " & "))
+		}
+		buf.WriteString(" {\n")
+		printFields(buf, t.Fields())
+		buf.WriteString("}")
+	case *graphql.Interface:
+		fmt.Fprintf(buf, "interface %s {\n", t.Name())
+		printFields(buf, t.Fields())
+		buf.WriteString("}")
+	case *graphql.Union:
+		names := make([]string, 0, len(t.Types()))
+		for _, member := range t.Types() {
+			names = append(names, member.Name())
+		}
+		sort.Strings(names)
+		fmt.Fprintf(buf, "union %s = %s", t.Name(), strings.Join(names, " | "))
+	}
+	return buf.String()
+}
+
+func printFields(buf *bytes.Buffer, fields graphql.FieldDefinitionMap) {
+	for _, name := range sortedKeys(fields) {
+		field := fields[name]
+		printDescription(buf, "  ", field.Description)
+		fmt.Fprintf(buf, "  %s%s: %s%s\n", name, printArgs(field.Args), field.Type, printDeprecated(field.DeprecationReason))
+	}
+}
+
+// printArgs prints the arguments of a field. When any of the arguments has a
+// description, each argument is printed in its own line.
+func printArgs(args []*graphql.Argument) string {
+	if len(args) == 0 {
+		return ""
+	}
+	sorted := append([]*graphql.Argument{}, args...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name() < sorted[j].Name()
+	})
+
+	multiline := false
+	for _, arg := range sorted {
+		multiline = multiline || arg.Description() != ""
+	}
+
+	if !multiline {
+		parts := make([]string, 0, len(sorted))
+		for _, arg := range sorted {
+			parts = append(parts, fmt.Sprintf("%s: %s%s", arg.Name(), arg.Type, printDefaultValue(arg.DefaultValue, arg.Type)))
+		}
+		return "(" + strings.Join(parts, ", ") + ")"
+	}
+
+	buf := &bytes.Buffer{}
+	buf.WriteString("(\n")
+	for _, arg := range sorted {
+		printDescription(buf, "    ", arg.Description())
+		fmt.Fprintf(buf, "    %s: %s%s\n", arg.Name(), arg.Type, printDefaultValue(arg.DefaultValue, arg.Type))
+	}
+	buf.WriteString("  )")
+	return buf.String()
+}
+
+func printDescription(buf *bytes.Buffer, indent string, description string) {
+	if description == "" {
+		return
+	}
+	description = strings.ReplaceAll(description, `"""`, `\"""`)
+	if !strings.Contains(description, "\n") && !strings.HasSuffix(description, `"`) {
+		fmt.Fprintf(buf, "%s\"\"\"%s\"\"\"\n", indent, description)
+		return
+	}
+	fmt.Fprintf(buf, "%s\"\"\"\n", indent)
+	for _, line := range strings.Split(description, "\n") {
+		fmt.Fprintf(buf, "%s%s\n", indent, line)
+	}
+	fmt.Fprintf(buf, "%s\"\"\"\n", indent)
+}
+
+func printDeprecated(reason string) string {
+	if reason == "" {
+		return ""
+	}
+	return fmt.Sprintf(" @deprecated(reason: %s)", printString(reason))
+}
+
+func printDefaultValue(value interface{}, t graphql.Type) string {
+	if value == nil {
+		return ""
+	}
+	return " = " + printValue(value, t)
+}
+
+// printValue prints a Go value as a GraphQL literal of the type t.
+func printValue(value interface{}, t graphql.Type) string {
+	if nonNull, ok := t.(*graphql.NonNull); ok {
+		t = nonNull.OfType
+	}
+	if value == nil {
+		return "null"
+	}
+
+	v := reflect.ValueOf(value)
+	switch t := t.(type) {
+	case *graphql.Enum:
+		for _, enumValue := range t.Values() {
+			if reflect.DeepEqual(enumValue.Value, value) {
+				return enumValue.Name
+			}
+		}
+	case *graphql.List:
+		if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+			return printValue(value, t.OfType)
+		}
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			parts = append(parts, printValue(v.Index(i).Interface(), t.OfType))
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case *graphql.InputObject:
+		if m, ok := value.(map[string]interface{}); ok {
+			fields := t.Fields()
+			parts := make([]string, 0, len(m))
+			for _, name := range sortedKeys(m) {
+				var fieldType graphql.Type
+				if field, ok := fields[name]; ok {
+					fieldType = field.Type
+				}
+				parts = append(parts, fmt.Sprintf("%s: %s", name, printValue(m[name], fieldType)))
+			}
+			return "{" + strings.Join(parts, ", ") + "}"
+		}
+	}
+
+	if m, ok := value.(encoding.TextMarshaler); ok {
+		text, err := m.MarshalText()
+		if err == nil {
+			return printString(string(text))
+		}
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return printString(v.String())
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return fmt.Sprint(value)
+	}
+	return printString(fmt.Sprint(value))
+}
+
+// printString prints a GraphQL string literal. The escaping rules of GraphQL
+// strings are compatible with JSON.
+func printString(s string) string {
+	buf := &bytes.Buffer{}
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(s)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
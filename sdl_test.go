@@ -0,0 +1,125 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+type SDLPerson struct {
+	Name      string       `graphql:"!name"`
+	Age       int          `graphql:"age"`
+	Friends   []*SDLPerson `graphql:"friends"`
+	CreatedAt time.Time    `graphql:"createdAt"`
+}
+
+type SDLPersonArgs struct {
+	Name string `graphql:"!name"`
+}
+
+type SDLCreatePersonArgs struct {
+	Person SDLPerson `graphql:"!person"`
+}
+
+const sdlDateTime = `"""The ` + "`DateTime`" + ` scalar type represents a DateTime. The DateTime is serialized as an RFC 3339 quoted string"""
+scalar DateTime
+`
+
+func TestEncoder_PrintSDL(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	_, err := enc.Struct(SDLPerson{}, gql_auto.WithDescription("A person"))
+	ass.NoError(err)
+	_, err = enc.Args(SDLCreatePersonArgs{})
+	ass.NoError(err)
+
+	ass.Equal(sdlDateTime+`
+"""A person"""
+type SDLPerson {
+  age: Int
+  createdAt: DateTime
+  friends: [SDLPerson]
+  name: String!
+}
+
+input SDLPersonInput {
+  age: Int
+  createdAt: DateTime
+  friends: [SDLPersonInput]
+  name: String!
+}
+`, enc.PrintSDL())
+}
+
+func TestPrintSchemaSDL(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	color := graphql.NewEnum(graphql.EnumConfig{
+		Name:        "Color",
+		Description: "A color\nof the rainbow",
+		Values: graphql.EnumValueConfigMap{
+			"RED":   &graphql.EnumValueConfig{Value: 1},
+			"GREEN": &graphql.EnumValueConfig{Value: 2, DeprecationReason: "use \"RED\""},
+		},
+	})
+
+	enc := gql_auto.NewEncoder()
+	schema, err := enc.SchemaBuilder().
+		Query("person", SDLPersonArgs{}, SDLPerson{}, nil,
+			gql_auto.WithDescription("Finds a person"),
+			gql_auto.WithDeprecationReason("use persons"),
+		).
+		Query("color", nil, 0, nil, gql_auto.WithType(color), gql_auto.WithArgs(enc, struct {
+			Default int `graphql:"default"`
+		}{})).
+		Mutation("createPerson", SDLCreatePersonArgs{}, SDLPerson{}, nil).
+		Build()
+	ass.NoError(err)
+
+	schema.QueryType().Fields()["color"].Args[0].Type = color
+	schema.QueryType().Fields()["color"].Args[0].DefaultValue = 1
+	schema.QueryType().Fields()["color"].Args[0].PrivateDescription = "The default color"
+
+	ass.Equal(`"""
+A color
+of the rainbow
+"""
+enum Color {
+  GREEN @deprecated(reason: "use \"RED\"")
+  RED
+}
+
+`+sdlDateTime+`
+type Mutation {
+  createPerson(person: SDLPersonInput!): SDLPerson
+}
+
+type Query {
+  color(
+    """The default color"""
+    default: Color = RED
+  ): Color
+  """Finds a person"""
+  person(name: String!): SDLPerson @deprecated(reason: "use persons")
+}
+
+type SDLPerson {
+  age: Int
+  createdAt: DateTime
+  friends: [SDLPerson]
+  name: String!
+}
+
+input SDLPersonInput {
+  age: Int
+  createdAt: DateTime
+  friends: [SDLPersonInput]
+  name: String!
+}
+`, gql_auto.PrintSchemaSDL(schema))
+}
@@ -0,0 +1,242 @@
+package gql_auto
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+var (
+	driverValuerType = reflect.TypeOf(new(driver.Valuer)).Elem()
+	sqlScannerType   = reflect.TypeOf(new(sql.Scanner)).Elem()
+	optionalType     = reflect.TypeOf(new(optional)).Elem()
+)
+
+// Optional is a value that may be absent. It is represented by the type of
+// its value, which is null when the optional is not valid:
+//
+// ```
+//
+//	type Person struct {
+//	    Nickname gql_auto.Optional[string] // String
+//	}
+//
+// ```
+//
+// The arguments of type Optional are valid when they are informed and are
+// not null.
+type Optional[T any] struct {
+	Value T
+	Valid bool
+}
+
+// Some returns a valid optional with the value informed.
+func Some[T any](value T) Optional[T] {
+	return Optional[T]{Value: value, Valid: true}
+}
+
+// None returns an optional without value.
+func None[T any]() Optional[T] {
+	return Optional[T]{}
+}
+
+// Get returns the value of the optional and whether it is valid.
+func (o Optional[T]) Get() (T, bool) {
+	return o.Value, o.Valid
+}
+
+func (Optional[T]) optional() {}
+
+// optional is implemented by every `Optional`.
+type optional interface {
+	optional()
+}
+
+// nullWrapperValue returns the field that holds the value of a null wrapper.
+// The null wrappers are the structs with a "Valid" flag and a value, which
+// are either an `Optional` or a `driver.Valuer` like `sql.NullString`.
+func nullWrapperValue(t reflect.Type) (reflect.StructField, bool) {
+	if t.Kind() != reflect.Struct || t.NumField() != 2 {
+		return reflect.StructField{}, false
+	}
+	if !t.Implements(optionalType) && !reflect.PtrTo(t).Implements(driverValuerType) {
+		return reflect.StructField{}, false
+	}
+	valid, ok := t.FieldByName("Valid")
+	if !ok || valid.Type.Kind() != reflect.Bool {
+		return reflect.StructField{}, false
+	}
+	value := t.Field(1 - valid.Index[0])
+	if !value.IsExported() {
+		return reflect.StructField{}, false
+	}
+	return value, true
+}
+
+// isNullWrapper checks if the values of the Go type t are represented by
+// the type of the value they wrap.
+func (enc *Encoder) isNullWrapper(t reflect.Type) bool {
+	t = cacheKey(t)
+	if enc.isRegisteredScalar(t) {
+		return false
+	}
+	_, ok := nullWrapperValue(t)
+	return ok
+}
+
+// isDriverValuer checks if the values of the Go type t are structs
+// represented by the `JSON` scalar with the value of their `driver.Valuer`.
+func (enc *Encoder) isDriverValuer(t reflect.Type) bool {
+	t = cacheKey(t)
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	pt := reflect.PtrTo(t)
+	if !pt.Implements(driverValuerType) || enc.isMarshaler(t) {
+		return false
+	}
+	if r, ok := enc.getType(t); ok {
+		return r == JSON
+	}
+	if t == timeType || t == uuidType || isGraphqlEnum(t) ||
+		t.Implements(graphqlTypedType) || pt.Implements(graphqlTypedType) {
+		return false
+	}
+	_, wrapper := nullWrapperValue(t)
+	return !wrapper
+}
+
+// isRegisteredScalar checks if the Go type t was registered as a custom
+// scalar.
+func (enc *Encoder) isRegisteredScalar(t reflect.Type) bool {
+	r, ok := enc.getType(t)
+	if !ok {
+		return false
+	}
+	scalar, ok := r.(*graphql.Scalar)
+	return ok && enc.names[scalar.Name()] == t
+}
+
+// sqlValueUnwrap returns the function that unwraps the values of the type t
+// when they are null wrappers or `driver.Valuer` structs, or the lists of
+// them. It returns nil when the values of t are not unwrapped.
+//
+// The functions are built in advance, so the resolvers do not read the
+// types of the encoder.
+func (enc *Encoder) sqlValueUnwrap(t reflect.Type) func(v reflect.Value) (interface{}, error) {
+	switch {
+	case t.Kind() == reflect.Ptr:
+		elem := enc.sqlValueUnwrap(t.Elem())
+		if elem == nil {
+			return nil
+		}
+		return func(v reflect.Value) (interface{}, error) {
+			if v.IsNil() {
+				return nil, nil
+			}
+			return elem(v.Elem())
+		}
+	case enc.isNullWrapper(t):
+		field, _ := nullWrapperValue(t)
+		value := enc.sqlValueUnwrap(field.Type)
+		return func(v reflect.Value) (interface{}, error) {
+			if !v.FieldByName("Valid").Bool() {
+				return nil, nil
+			}
+			if value == nil {
+				return v.FieldByIndex(field.Index).Interface(), nil
+			}
+			return value(v.FieldByIndex(field.Index))
+		}
+	case enc.isDriverValuer(t):
+		return driverValue
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		elem := enc.sqlValueUnwrap(t.Elem())
+		if elem == nil {
+			return nil
+		}
+		return func(v reflect.Value) (interface{}, error) {
+			if v.Kind() == reflect.Slice && v.IsNil() {
+				return nil, nil
+			}
+			r := make([]interface{}, v.Len())
+			for i := range r {
+				value, err := elem(v.Index(i))
+				if err != nil {
+					return nil, err
+				}
+				r[i] = value
+			}
+			return r, nil
+		}
+	}
+	return nil
+}
+
+// driverValue returns the value of the `driver.Valuer` v. Bytes are
+// represented by strings.
+func driverValue(v reflect.Value) (interface{}, error) {
+	value, err := addressableCopy(v).Interface().(driver.Valuer).Value()
+	if err != nil {
+		return nil, err
+	}
+	if b, ok := value.([]byte); ok {
+		return string(b), nil
+	}
+	return value, nil
+}
+
+// sqlValueResolve unwraps the values of the type t returned by the resolver
+// next, so they are serialized by the type of the values they hold. The
+// resolver is returned as is when the values of t are not unwrapped.
+func (enc *Encoder) sqlValueResolve(t reflect.Type, next graphql.FieldResolveFn) graphql.FieldResolveFn {
+	unwrap := enc.sqlValueUnwrap(t)
+	if unwrap == nil {
+		return next
+	}
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		v, err := next(p)
+		if err != nil || v == nil {
+			return v, err
+		}
+		// Custom resolvers can return values of other types.
+		rv := reflect.ValueOf(v)
+		if rv.Type() != t {
+			return v, nil
+		}
+		return unwrap(rv)
+	}
+}
+
+// decodeNullWrapper sets the null wrapper dst with src. The wrapper is valid
+// when src is not null.
+func (enc *Encoder) decodeNullWrapper(path string, src interface{}, dst reflect.Value) error {
+	field, _ := nullWrapperValue(dst.Type())
+	r := reflect.New(dst.Type()).Elem()
+	if src != nil {
+		err := enc.decodeValue(path, src, r.FieldByIndex(field.Index))
+		if err != nil {
+			return err
+		}
+		r.FieldByName("Valid").SetBool(true)
+	}
+	dst.Set(r)
+	return nil
+}
+
+// decodeSQLScanner sets the `sql.Scanner` dst with src.
+func decodeSQLScanner(path string, src interface{}, dst reflect.Value) error {
+	if !reflect.PtrTo(dst.Type()).Implements(sqlScannerType) {
+		return NewErrDecode(path, fmt.Errorf("cannot decode %T into %s, it does not implement sql.Scanner", src, dst.Type()))
+	}
+	ptr := reflect.New(dst.Type())
+	err := ptr.Interface().(sql.Scanner).Scan(src)
+	if err != nil {
+		return NewErrDecode(path, err)
+	}
+	dst.Set(ptr.Elem())
+	return nil
+}
@@ -0,0 +1,193 @@
+package gql_auto_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+// SQLPoint is stored as text by its driver.Valuer.
+type SQLPoint struct {
+	X, Y int
+}
+
+func (p SQLPoint) Value() (driver.Value, error) {
+	return []byte(fmt.Sprintf("%d,%d", p.X, p.Y)), nil
+}
+
+func (p *SQLPoint) Scan(src interface{}) error {
+	s, ok := src.(string)
+	if !ok {
+		return fmt.Errorf("cannot scan %T into a point", src)
+	}
+	_, err := fmt.Sscanf(s, "%d,%d", &p.X, &p.Y)
+	return err
+}
+
+type SQLAddress struct {
+	City string `graphql:"city"`
+}
+
+type SQLRow struct {
+	Name     sql.NullString                `graphql:"name"`
+	Age      sql.NullInt64                 `graphql:"age"`
+	Score    sql.NullFloat64               `graphql:"score"`
+	Active   sql.NullBool                  `graphql:"active"`
+	Born     sql.NullTime                  `graphql:"born"`
+	Nickname gql_auto.Optional[string]     `graphql:"nickname"`
+	Address  gql_auto.Optional[SQLAddress] `graphql:"address"`
+	Ranks    []sql.NullInt32               `graphql:"ranks"`
+	Note     *sql.NullString               `graphql:"note"`
+	Timeout  time.Duration                 `graphql:"timeout"`
+	Location SQLPoint                      `graphql:"location"`
+}
+
+func (r SQLRow) ResolveTitle() sql.NullString {
+	return r.Name
+}
+
+type SQLArgs struct {
+	Nickname gql_auto.Optional[string] `graphql:"nickname"`
+	Age      sql.NullInt64             `graphql:"age"`
+	Timeout  time.Duration             `graphql:"timeout"`
+	Location SQLPoint                  `graphql:"location"`
+}
+
+func TestEncoder_SQLTypes(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder(gql_auto.WithNullability(gql_auto.InferFromPointers))
+	obj, err := enc.Struct(SQLRow{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal("String", fields["name"].Type.String())
+	ass.Equal("Int", fields["age"].Type.String())
+	ass.Equal("Float", fields["score"].Type.String())
+	ass.Equal("Boolean", fields["active"].Type.String())
+	ass.Equal("DateTime", fields["born"].Type.String())
+	ass.Equal("String", fields["nickname"].Type.String())
+	ass.Equal("SQLAddress", fields["address"].Type.String())
+	ass.Equal("[Int]", fields["ranks"].Type.String())
+	ass.Equal("String", fields["note"].Type.String())
+	ass.Equal("Duration!", fields["timeout"].Type.String())
+	ass.Equal("JSON", fields["location"].Type.String())
+	ass.Equal("String", fields["title"].Type.String())
+
+	var row SQLRow
+	schema, err := enc.SchemaBuilder().
+		Query("row", nil, SQLRow{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return row, nil
+		}).
+		Build()
+	ass.NoError(err)
+
+	query := `{ row { name age score active born nickname address { city } ranks note timeout location title } }`
+
+	row = SQLRow{
+		Name:     sql.NullString{String: "Grace", Valid: true},
+		Age:      sql.NullInt64{Int64: 85, Valid: true},
+		Score:    sql.NullFloat64{Float64: 9.5, Valid: true},
+		Active:   sql.NullBool{Bool: false, Valid: true},
+		Born:     sql.NullTime{Time: time.Date(1906, time.December, 9, 0, 0, 0, 0, time.UTC), Valid: true},
+		Nickname: gql_auto.Some("Amazing Grace"),
+		Address:  gql_auto.Some(SQLAddress{City: "New York"}),
+		Ranks:    []sql.NullInt32{{Int32: 1, Valid: true}, {}},
+		Note:     &sql.NullString{String: "admiral", Valid: true},
+		Timeout:  90 * time.Minute,
+		Location: SQLPoint{X: 1, Y: 2},
+	}
+	res := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"row": map[string]interface{}{
+			"name":     "Grace",
+			"age":      85,
+			"score":    9.5,
+			"active":   false,
+			"born":     "1906-12-09T00:00:00Z",
+			"nickname": "Amazing Grace",
+			"address":  map[string]interface{}{"city": "New York"},
+			"ranks":    []interface{}{1, nil},
+			"note":     "admiral",
+			"timeout":  "1h30m0s",
+			"location": "1,2",
+			"title":    "Grace",
+		},
+	}, res.Data)
+
+	row = SQLRow{Note: &sql.NullString{}}
+	res = graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"row": map[string]interface{}{
+			"name":     nil,
+			"age":      nil,
+			"score":    nil,
+			"active":   nil,
+			"born":     nil,
+			"nickname": nil,
+			"address":  nil,
+			"ranks":    nil,
+			"note":     nil,
+			"timeout":  "0s",
+			"location": "0,0",
+			"title":    nil,
+		},
+	}, res.Data)
+}
+
+func TestDecoder_SQLTypes(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	args, err := enc.Args(SQLArgs{})
+	ass.NoError(err)
+	ass.Equal("String", args["nickname"].Type.String())
+	ass.Equal("Int", args["age"].Type.String())
+	ass.Equal("Duration", args["timeout"].Type.String())
+	ass.Equal("JSON", args["location"].Type.String())
+
+	var decoded SQLArgs
+	schema, err := enc.SchemaBuilder().
+		Query("row", SQLArgs{}, SQLRow{}, func(p graphql.ResolveParams) (interface{}, error) {
+			decoded = SQLArgs{}
+			err := enc.DecodeArgs(p, &decoded)
+			return SQLRow{}, err
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ row(nickname: "Grace", age: 85, timeout: "P1DT1H30M", location: "3,4") { name } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(SQLArgs{
+		Nickname: gql_auto.Some("Grace"),
+		Age:      sql.NullInt64{Int64: 85, Valid: true},
+		Timeout:  25*time.Hour + 30*time.Minute,
+		Location: SQLPoint{X: 3, Y: 4},
+	}, decoded)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ row(timeout: "-PT0.5S") { name } }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(SQLArgs{Nickname: gql_auto.None[string](), Timeout: -500 * time.Millisecond}, decoded)
+
+	for _, arg := range []string{`timeout: "P1M"`, `timeout: "PT"`, `location: 3`} {
+		res = graphql.Do(graphql.Params{
+			Schema:        schema,
+			RequestString: `{ row(` + arg + `) { name } }`,
+		})
+		ass.Len(res.Errors, 1, arg)
+	}
+}
@@ -0,0 +1,224 @@
+package gql_auto
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+)
+
+// The companion tags set the options of a field without the escaping
+// issues of the "graphql" tag, i.e. descriptions with commas. They take
+// precedence over the options of the "graphql" tag.
+const (
+	descriptionTag  = "gqldesc"
+	deprecationTag  = "gqldeprecated"
+	defaultValueTag = "gqldefault"
+)
+
+// fieldTag is the parsed "graphql" tag of a struct field:
+//
+// ```
+//
+//	type T struct {
+//	    Name string `graphql:"!name,description=The name, or the nickname,deprecated=use fullName"`
+//	    Age  int    `graphql:"age,default=42,nullable"`
+//	}
+//
+// ```
+//
+// * name: The name of the field, a leading "!" makes it NonNull;
+// * description: The description of the field;
+// * deprecated: The deprecation reason of the field;
+// * default: The default value of an argument or input field, as a GraphQL
+// value written in JSON for lists and input objects;
+// * nullable: The field is nullable;
+// * key: The field is the key of the struct, i.e. its ID, used by
+// `MemoryStore`. It is optional in the input objects, so the key can be
+// assigned when the value is created.
+//
+// The values go until the next option, so they can contain commas.
+type fieldTag struct {
+	name              string
+	nonNull           bool
+	nullable          bool
+	key               bool
+	description       string
+	deprecationReason string
+	defaultValue      string
+	hasDefault        bool
+	// asString is set by the ",string" option of the json tag, the numbers
+	// and booleans are represented by strings.
+	asString bool
+}
+
+// parseFieldTag parses the "graphql" tag and the companion tags of a field.
+func parseFieldTag(field reflect.StructField) (fieldTag, error) {
+	var r fieldTag
+	tag := field.Tag.Get("graphql")
+	if len(tag) > 0 && tag[0] == '!' {
+		r.nonNull = true
+		tag = tag[1:]
+	}
+
+	parts := strings.Split(tag, ",")
+	r.name = parts[0]
+
+	// value points to the value of the last option, which is continued
+	// by the parts that are not options.
+	var value *string
+	for _, part := range parts[1:] {
+		key, v, hasValue := strings.Cut(part, "=")
+		switch {
+		case hasValue && key == "description":
+			r.description = v
+			value = &r.description
+		case hasValue && key == "deprecated":
+			r.deprecationReason = v
+			value = &r.deprecationReason
+		case hasValue && key == "default":
+			r.defaultValue, r.hasDefault = v, true
+			value = &r.defaultValue
+		case !hasValue && key == "nullable":
+			r.nullable = true
+			value = nil
+		case !hasValue && key == "key":
+			r.key = true
+			value = nil
+		case value != nil:
+			*value += "," + part
+		default:
+			return r, fmt.Errorf("unknown graphql tag option '%s'", part)
+		}
+	}
+
+	_, jsonOptions, _ := strings.Cut(field.Tag.Get("json"), ",")
+	for _, option := range strings.Split(jsonOptions, ",") {
+		if option == "string" {
+			r.asString = true
+		}
+	}
+
+	if v, ok := field.Tag.Lookup(descriptionTag); ok {
+		r.description = v
+	}
+	if v, ok := field.Tag.Lookup(deprecationTag); ok {
+		r.deprecationReason = v
+	}
+	if v, ok := field.Tag.Lookup(defaultValueTag); ok {
+		r.defaultValue, r.hasDefault = v, true
+	}
+
+	if r.nonNull && r.nullable {
+		return r, fmt.Errorf("field cannot be both NonNull and nullable")
+	}
+	return r, nil
+}
+
+// outputFieldTag returns the tag of the object field built from the struct
+// field. ok is false when the field must be ignored.
+//
+// The name is the name of the "graphql" tag, or the name of the "json" tag
+// when there is no "graphql" tag. Without tags, the name of the struct field
+// is converted to lower camel case.
+func outputFieldTag(field reflect.StructField) (tag fieldTag, ok bool, err error) {
+	// if is tagged with graphql or json, but is not exported, ignore it
+	if ignoredByTag(field) {
+		return fieldTag{}, false, nil
+	}
+	tag, err = parseFieldTag(field)
+	if _, tagged := field.Tag.Lookup("graphql"); !tagged {
+		// if there is no graphql tag look for a json tag
+		tag.name, _, _ = strings.Cut(field.Tag.Get("json"), ",")
+	}
+	// if the field name was not set by the tag
+	// build the field name by creating a camel case from the struct field name
+	if tag.name == "" {
+		tag.name = toLowerCamelCase(field.Name)
+	}
+	return tag, true, err
+}
+
+func outputFieldName(field reflect.StructField) (string, bool) {
+	tag, ok, _ := outputFieldTag(field)
+	return tag.name, ok
+}
+
+// inputFieldTag returns the tag of the argument or input field built from
+// the struct field. ok is false when the field must be ignored.
+//
+// The name is the name of the "graphql" tag, or the name of the "json" tag
+// when there is no "graphql" tag, or the name of the struct field when there
+// are no tags, converted to lower camel case.
+func inputFieldTag(field reflect.StructField) (tag fieldTag, ok bool, err error) {
+	// if the field is not exported, ignore it
+	if field.PkgPath != "" {
+		return fieldTag{}, false, nil
+	}
+	// if is tagged with graphql or json, but is not exported, ignore it
+	if ignoredByTag(field) {
+		return fieldTag{}, false, nil
+	}
+	tag, err = parseFieldTag(field)
+	if _, tagged := field.Tag.Lookup("graphql"); !tagged {
+		tag.name, _, _ = strings.Cut(field.Tag.Get("json"), ",")
+	}
+	if tag.name == "" {
+		tag.name = field.Name
+	}
+	tag.name = toLowerCamelCase(tag.name)
+	return tag, true, err
+}
+
+func inputFieldName(field reflect.StructField) (string, bool) {
+	tag, ok, _ := inputFieldTag(field)
+	return tag.name, ok
+}
+
+// parseDefaultValue converts the default value of a tag to a value of the
+// input type t, as it would be received by a resolver.
+func parseDefaultValue(t graphql.Type, s string) (interface{}, error) {
+	switch t := t.(type) {
+	case *graphql.NonNull:
+		return parseDefaultValue(t.OfType, s)
+	case *graphql.Enum:
+		for _, value := range t.Values() {
+			if value.Name == s {
+				return value.Value, nil
+			}
+		}
+		return nil, fmt.Errorf("'%s' is not a value of %s", s, t)
+	case *graphql.Scalar:
+		switch t {
+		case graphql.Int:
+			return strconv.Atoi(s)
+		case graphql.Float:
+			return strconv.ParseFloat(s, 64)
+		case graphql.Boolean:
+			return strconv.ParseBool(s)
+		case graphql.String, graphql.ID:
+			return s, nil
+		case JSON:
+			return parseJSONDefaultValue(s)
+		}
+		r := t.ParseValue(s)
+		if r == nil {
+			return nil, fmt.Errorf("'%s' is not a valid %s", s, t)
+		}
+		return r, nil
+	}
+	// Lists and input objects are written in JSON.
+	return parseJSONDefaultValue(s)
+}
+
+func parseJSONDefaultValue(s string) (interface{}, error) {
+	var r interface{}
+	err := json.Unmarshal([]byte(s), &r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid default value: %w", err)
+	}
+	return r, nil
+}
@@ -0,0 +1,147 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+type TagUser struct {
+	Name     string `graphql:"!name,description=The name, or the nickname, of the user"`
+	Nickname string `graphql:"nickname,deprecated=use name"`
+	Email    string `graphql:"email" gqldesc:"The email, verified"`
+	Age      int    `graphql:"age,default=42,nullable"`
+}
+
+type TagSearchArgs struct {
+	Query  string   `graphql:"!query,description=The text searched"`
+	Limit  int      `graphql:"limit,default=10"`
+	Ratio  float64  `graphql:"ratio,default=0.5"`
+	Exact  bool     `graphql:"exact,default=true"`
+	Tags   []string `graphql:"tags" gqldefault:"[\"a\", \"b\"]"`
+	Status TagStatus
+}
+
+type TagStatus string
+
+func (TagStatus) GraphqlEnumValues() []gql_auto.EnumValue {
+	return []gql_auto.EnumValue{{Value: "ACTIVE"}, {Value: "INACTIVE"}}
+}
+
+type TagStatusArgs struct {
+	Status TagStatus `graphql:"status,default=INACTIVE"`
+}
+
+type TagInvalidOption struct {
+	Name string `graphql:"name,unknown"`
+}
+
+type TagInvalidNullability struct {
+	Name string `graphql:"!name,nullable"`
+}
+
+type TagInvalidDefault struct {
+	Limit int `graphql:"limit,default=ten"`
+}
+
+func TestEncoder_StructOfTags(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	obj, err := enc.Struct(TagUser{})
+	ass.NoError(err)
+	fields := obj.Fields()
+	ass.Equal("String!", fields["name"].Type.String())
+	ass.Equal("The name, or the nickname, of the user", fields["name"].Description)
+	ass.Equal("use name", fields["nickname"].DeprecationReason)
+	ass.Equal("The email, verified", fields["email"].Description)
+	ass.Equal("Int", fields["age"].Type.String())
+
+	_, err = enc.Struct(TagInvalidOption{})
+	ass.ErrorContains(err, "unknown graphql tag option 'unknown'")
+	_, err = enc.Struct(TagInvalidNullability{})
+	ass.ErrorContains(err, "cannot be both NonNull and nullable")
+}
+
+func TestEncoder_ArgsOfTags(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	args, err := enc.ArgsOf(reflect.TypeOf(TagSearchArgs{}))
+	ass.NoError(err)
+	ass.Equal("The text searched", args["query"].Description)
+	ass.Equal(10, args["limit"].DefaultValue)
+	ass.Equal(0.5, args["ratio"].DefaultValue)
+	ass.Equal(true, args["exact"].DefaultValue)
+	ass.Equal([]interface{}{"a", "b"}, args["tags"].DefaultValue)
+	ass.Nil(args["status"].DefaultValue)
+
+	_, err = enc.ArgsOf(reflect.TypeOf(TagInvalidDefault{}))
+	ass.ErrorContains(err, "invalid syntax")
+
+	var decoded TagSearchArgs
+	var status TagStatusArgs
+	schema, err := enc.SchemaBuilder().
+		Query("search", TagSearchArgs{}, []string{}, func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, &decoded)
+			return decoded.Tags, err
+		}).
+		Query("status", TagStatusArgs{}, TagStatus(""), func(p graphql.ResolveParams) (interface{}, error) {
+			err := enc.DecodeArgs(p, &status)
+			return status.Status, err
+		}).
+		Build()
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ search(query: "q") status }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(TagSearchArgs{
+		Query: "q",
+		Limit: 10,
+		Ratio: 0.5,
+		Exact: true,
+		Tags:  []string{"a", "b"},
+	}, decoded)
+	ass.Equal(TagStatus("INACTIVE"), status.Status)
+	ass.Equal(map[string]interface{}{
+		"search": []interface{}{"a", "b"},
+		"status": "INACTIVE",
+	}, res.Data)
+}
+
+func TestEncoder_PrintSDLTags(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	_, err := enc.InputObjectOf(reflect.TypeOf(TagStatusArgs{}))
+	ass.NoError(err)
+	_, err = enc.Struct(TagUser{})
+	ass.NoError(err)
+
+	ass.Equal(`enum TagStatus {
+  ACTIVE
+  INACTIVE
+}
+
+input TagStatusArgsInput {
+  status: TagStatus = INACTIVE
+}
+
+type TagUser {
+  age: Int
+  """The email, verified"""
+  email: String
+  """The name, or the nickname, of the user"""
+  name: String!
+  nickname: String @deprecated(reason: "use name")
+}
+`, enc.PrintSDL())
+}
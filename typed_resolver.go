@@ -0,0 +1,76 @@
+package gql_auto
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/graphql-go/graphql"
+)
+
+// ResolverFunc is a resolver that receives its arguments already decoded
+// into the Args struct and returns a typed result.
+type ResolverFunc[Args any, Out any] func(ctx context.Context, args Args) (Out, error)
+
+// ResolverOf builds a complete `graphql.Field` from a typed resolver.
+//
+// The arguments of the field are obtained from Args using `ArgsOf`, and its
+// type from Out. The resolver decodes the arguments into a new Args before
+// calling fn. The options are applied after the field is built, so they can
+// override any of its properties.
+func ResolverOf[Args any, Out any](enc *Encoder, fn ResolverFunc[Args, Out], options ...Option) (graphql.Field, error) {
+	r := graphql.Field{}
+
+	argsType := reflect.TypeOf((*Args)(nil)).Elem()
+	args, err := enc.ArgsOf(argsType)
+	if err != nil {
+		return graphql.Field{}, err
+	}
+	r.Args = args
+
+	outType := reflect.TypeOf((*Out)(nil)).Elem()
+	fieldType, err := enc.TypeOf(outType)
+	if err != nil {
+		return graphql.Field{}, err
+	}
+	r.Type = fieldType
+
+	r.Resolve = func(p graphql.ResolveParams) (interface{}, error) {
+		var args Args
+		err := enc.DecodeArgs(p, &args)
+		if err != nil {
+			return nil, err
+		}
+		ctx := p.Context
+		if ctx == nil {
+			ctx = context.Background()
+		}
+		return fn(ctx, args)
+	}
+
+	for _, option := range options {
+		err = option.Apply(&r)
+		if err != nil {
+			return graphql.Field{}, err
+		}
+	}
+
+	return r, nil
+}
+
+// Resolver builds a complete `graphql.Field` from a typed resolver using the
+// `DefaultEncoder`.
+//
+// ```
+//
+//	field := gql_auto.Resolver(func(ctx context.Context, args PersonArgs) (*Person, error) {
+//	    return findPerson(ctx, args.Name)
+//	})
+//
+// ```
+func Resolver[Args any, Out any](fn ResolverFunc[Args, Out], options ...Option) graphql.Field {
+	r, err := ResolverOf(DefaultEncoder, fn, options...)
+	if err != nil {
+		panic(err.Error())
+	}
+	return r
+}
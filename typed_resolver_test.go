@@ -0,0 +1,87 @@
+package gql_auto_test
+
+import (
+	"context"
+	"errors"
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type ResolverHero struct {
+	Name string `graphql:"!name"`
+	Age  int    `graphql:"age"`
+}
+
+type ResolverHeroArgs struct {
+	Name string `graphql:"!name"`
+}
+
+func TestResolverOf(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	enc := gql_auto.NewEncoder()
+	hero, err := gql_auto.ResolverOf(enc, func(ctx context.Context, args ResolverHeroArgs) (*ResolverHero, error) {
+		if args.Name == "nobody" {
+			return nil, errors.New("not found")
+		}
+		return &ResolverHero{Name: args.Name, Age: 42}, nil
+	}, gql_auto.WithDescription("Finds a hero"))
+	ass.NoError(err)
+	ass.Equal("ResolverHero", hero.Type.String())
+	ass.Equal("Finds a hero", hero.Description)
+	ass.Contains(hero.Args, "name")
+
+	names, err := gql_auto.ResolverOf(enc, func(ctx context.Context, args struct{}) ([]string, error) {
+		return []string{"Duke", "Scarlett"}, nil
+	})
+	ass.NoError(err)
+	ass.Equal("[String]", names.Type.String())
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{
+		Query: graphql.NewObject(graphql.ObjectConfig{
+			Name: "Query",
+			Fields: graphql.Fields{
+				"hero":  &hero,
+				"names": &names,
+			},
+		}),
+	})
+	ass.NoError(err)
+
+	res := graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ hero(name: "Snake Eyes") { name age } names }`,
+	})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"hero":  map[string]interface{}{"name": "Snake Eyes", "age": 42},
+		"names": []interface{}{"Duke", "Scarlett"},
+	}, res.Data)
+
+	res = graphql.Do(graphql.Params{
+		Schema:        schema,
+		RequestString: `{ hero(name: "nobody") { name } }`,
+	})
+	ass.Len(res.Errors, 1)
+	ass.Equal("not found", res.Errors[0].Message)
+}
+
+func TestResolver(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	field := gql_auto.Resolver(func(ctx context.Context, args ResolverHeroArgs) (ResolverHero, error) {
+		return ResolverHero{Name: args.Name}, nil
+	})
+	ass.Equal("ResolverHero", field.Type.String())
+	ass.NotNil(field.Resolve)
+
+	ass.Panics(func() {
+		gql_auto.Resolver(func(ctx context.Context, args string) (ResolverHero, error) {
+			return ResolverHero{}, nil
+		})
+	})
+}
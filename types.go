@@ -8,7 +8,8 @@ import (
 )
 
 // GraphqlTyped is the interface implemented by types that will provide a
-// special `graphql.Type`.
+// special `graphql.Type`. The method is called once per type, without holding
+// the lock of the encoder, so it can build other types, i.e. by `Struct`.
 type GraphqlTyped interface {
 	// GraphqlType returns the `graphql.Type` that represents the data type that
 	// implements this interface.
@@ -16,10 +17,12 @@ type GraphqlTyped interface {
 }
 
 var (
-	graphqlTypedType    = reflect.TypeOf(new(GraphqlTyped)).Elem()
-	graphqlResolverType = reflect.TypeOf(new(GraphqlResolver)).Elem()
-	timeType            = reflect.TypeOf(time.Time{})
-	uuidType            = reflect.TypeOf(uuid.UUID{})
+	graphqlTypedType         = reflect.TypeOf(new(GraphqlTyped)).Elem()
+	graphqlResolverType      = reflect.TypeOf(new(GraphqlResolver)).Elem()
+	graphqlValueResolverType = reflect.TypeOf(new(GraphqlValueResolver)).Elem()
+	timeType                 = reflect.TypeOf(time.Time{})
+	uuidType                 = reflect.TypeOf(uuid.UUID{})
+	durationType             = reflect.TypeOf(time.Duration(0))
 )
 
 func (enc *Encoder) buildFieldType(fieldType reflect.Type) (graphql.Type, error) {
@@ -27,19 +30,12 @@ func (enc *Encoder) buildFieldType(fieldType reflect.Type) (graphql.Type, error)
 		return r, nil
 	}
 
-	if fieldType.Kind() == reflect.Struct && (fieldType != timeType || fieldType != uuidType) {
-		// If the type is a struct, we need a pointer to that struct to
-		// check if it implements the interface.
-		tStruct := reflect.PtrTo(fieldType)
-		if tStruct.Implements(graphqlTypedType) {
-			vStruct := reflect.New(fieldType)
-			return vStruct.Interface().(GraphqlTyped).GraphqlType(), nil
-		}
-	}
-
-	if fieldType.Implements(graphqlTypedType) {
-		vStruct := reflect.New(fieldType.Elem())
-		return vStruct.Interface().(GraphqlTyped).GraphqlType(), nil
+	// A pointer to the type has the methods with both value and pointer
+	// receivers, so it is used to check if it implements the interface.
+	if t := cacheKey(fieldType); reflect.PtrTo(t).Implements(graphqlTypedType) {
+		return callback(enc, t, "GraphqlType", func() graphql.Type {
+			return reflect.New(t).Interface().(GraphqlTyped).GraphqlType()
+		})
 	}
 
 	// Check if it is a pointer or interface...
@@ -48,6 +44,10 @@ func (enc *Encoder) buildFieldType(fieldType reflect.Type) (graphql.Type, error)
 		fieldType = fieldType.Elem()
 	}
 
+	if isGraphqlEnum(fieldType) {
+		return enc.enumOf(fieldType, nil)
+	}
+
 	// Special case: If the type is the time.Time type.
 	if fieldType == timeType {
 		return graphql.DateTime, nil
@@ -56,12 +56,36 @@ func (enc *Encoder) buildFieldType(fieldType reflect.Type) (graphql.Type, error)
 	if fieldType == uuidType {
 		return graphql.String, nil
 	}
+	// Special case: If the type is a Connection of nodes.
+	if isConnection(fieldType) {
+		node := reflect.New(fieldType).Interface().(connection).connectionNode()
+		return enc.connectionOf(node)
+	}
+	// Special case: If the type is the time.Duration type.
+	if fieldType == durationType {
+		return DurationScalar, nil
+	}
+	// Special case: If the type wraps a value that can be null, i.e.
+	// sql.NullString or Optional[T].
+	if field, ok := nullWrapperValue(fieldType); ok {
+		return enc.buildFieldType(field.Type)
+	}
+	// Special case: If the type is a struct stored by its driver.Valuer.
+	if enc.isDriverValuer(fieldType) {
+		return JSON, nil
+	}
+	// Special case: If the type has a custom JSON or text representation.
+	if enc.isMarshaler(fieldType) {
+		return enc.marshalerScalar, nil
+	}
 
 	switch fieldType.Kind() {
 	case reflect.Struct:
-		return enc.StructOf(fieldType)
+		return enc.structOf(fieldType)
 	case reflect.Array, reflect.Slice:
-		return enc.ArrayOf(fieldType.Elem())
+		return enc.arrayOf(fieldType.Elem())
+	case reflect.Map:
+		return enc.mapOf(fieldType, enc.mapStrategy)
 	case reflect.Bool:
 		return graphql.Boolean, nil
 	case reflect.String:
@@ -69,10 +93,10 @@ func (enc *Encoder) buildFieldType(fieldType reflect.Type) (graphql.Type, error)
 	case
 		reflect.Int, reflect.Int64, reflect.Int32, reflect.Int16, reflect.Int8,
 		reflect.Uint, reflect.Uint64, reflect.Uint32, reflect.Uint16, reflect.Uint8:
-		return graphql.Int, nil
-	case
-		reflect.Float32, reflect.Float64, reflect.Complex64, reflect.Complex128:
+		return enc.intType(fieldType.Kind()), nil
+	case reflect.Float32, reflect.Float64:
 		return graphql.Float, nil
 	}
+	// Complex numbers have no GraphQL representation.
 	return nil, NewErrTypeNotRecognized(fieldType)
 }
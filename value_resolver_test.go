@@ -0,0 +1,99 @@
+package gql_auto_test
+
+import (
+	"github.com/SbstnErhrdt/gql_auto"
+	"github.com/graphql-go/graphql"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type ValueUser struct {
+	ID   string `graphql:"id"`
+	Name string `graphql:"name"`
+}
+
+var valueUsers = map[string]*ValueUser{
+	"1": {ID: "1", Name: "Grace"},
+	"2": {ID: "2", Name: "Ada"},
+}
+
+var valueEncoder = gql_auto.NewEncoder()
+
+var valueUserObject, _ = valueEncoder.Struct(ValueUser{})
+
+// ValueUserRef is a lazy reference to a user.
+type ValueUserRef struct {
+	ID string
+}
+
+func (*ValueUserRef) GraphqlType() graphql.Type {
+	return valueUserObject
+}
+
+func (r ValueUserRef) GraphqlResolveValue(p graphql.ResolveParams) (interface{}, error) {
+	return valueUsers[r.ID], nil
+}
+
+// ValueUserPtrRef is a lazy reference resolved by a pointer receiver.
+type ValueUserPtrRef struct {
+	ID string
+}
+
+func (*ValueUserPtrRef) GraphqlType() graphql.Type {
+	return valueUserObject
+}
+
+func (r *ValueUserPtrRef) GraphqlResolveValue(p graphql.ResolveParams) (interface{}, error) {
+	return valueUsers[r.ID], nil
+}
+
+type ValuePost struct {
+	Title    string           `graphql:"title"`
+	Author   ValueUserRef     `graphql:"author"`
+	Reviewer *ValueUserPtrRef `graphql:"reviewer"`
+	Editor   ValueUserPtrRef  `graphql:"editor"`
+}
+
+func TestEncoder_GraphqlValueResolver(t *testing.T) {
+	t.Parallel()
+	ass := assert.New(t)
+
+	var post ValuePost
+	schema, err := valueEncoder.SchemaBuilder().
+		Query("post", nil, ValuePost{}, func(p graphql.ResolveParams) (interface{}, error) {
+			return post, nil
+		}).
+		Build()
+	ass.NoError(err)
+
+	query := `{ post { title author { name } reviewer { name } editor { name } } }`
+
+	post = ValuePost{
+		Title:    "Notes",
+		Author:   ValueUserRef{ID: "1"},
+		Reviewer: &ValueUserPtrRef{ID: "2"},
+		Editor:   ValueUserPtrRef{ID: "1"},
+	}
+	res := graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"post": map[string]interface{}{
+			"title":    "Notes",
+			"author":   map[string]interface{}{"name": "Grace"},
+			"reviewer": map[string]interface{}{"name": "Ada"},
+			"editor":   map[string]interface{}{"name": "Grace"},
+		},
+	}, res.Data)
+
+	post = ValuePost{Title: "Draft", Author: ValueUserRef{ID: "2"}}
+	res = graphql.Do(graphql.Params{Schema: schema, RequestString: query})
+	ass.Empty(res.Errors)
+	ass.Equal(map[string]interface{}{
+		"post": map[string]interface{}{
+			"title":    "Draft",
+			"author":   map[string]interface{}{"name": "Ada"},
+			"reviewer": nil,
+			"editor":   nil,
+		},
+	}, res.Data)
+}